@@ -0,0 +1,257 @@
+package xmath
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+)
+
+var (
+	ErrAccumulatorInvalidData = errors.New("invalid accumulator data")
+)
+
+// Accumulator is a utility to compute statistics of a stream of weighted values without holding them.
+// It tracks count, total weight, compensated sum, min, max, mean and variance by Welford's algorithm.
+// Accumulator isn't safe for concurrent use. Each goroutine should use its own Accumulator, and the partials can be merged by Merge.
+// The zero value of Accumulator is ready to use.
+type Accumulator struct {
+	count  int64
+	weight float64
+	sum    float64
+	comp   float64
+	min    float64
+	max    float64
+	mean   float64
+	m2     float64
+}
+
+// NewAccumulator returns a new empty Accumulator.
+func NewAccumulator() *Accumulator {
+	return &Accumulator{}
+}
+
+// Add is synonym with AddWeighted(x, 1).
+func (a *Accumulator) Add(x float64) {
+	a.AddWeighted(x, 1)
+}
+
+// AddWeighted accumulates x with weight w.
+// The values with weight which is not greater than 0 are ignored. NaN values propagate to all statistics except count and weight.
+func (a *Accumulator) AddWeighted(x float64, w float64) {
+	if !(w > 0) {
+		return
+	}
+	if a.count <= 0 {
+		a.min, a.max = x, x
+	} else {
+		a.min, a.max = math.Min(a.min, x), math.Max(a.max, x)
+	}
+	a.count++
+	a.weight += w
+	a.addSum(x * w)
+	d := x - a.mean
+	a.mean += d * w / a.weight
+	a.m2 += w * d * (x - a.mean)
+}
+
+// addSum adds x to the sum by Neumaier's summation.
+func (a *Accumulator) addSum(x float64) {
+	t := a.sum + x
+	if math.Abs(a.sum) >= math.Abs(x) {
+		a.comp += (a.sum - t) + x
+	} else {
+		a.comp += (x - t) + a.sum
+	}
+	a.sum = t
+}
+
+// Merge accumulates the values of x into Accumulator.
+func (a *Accumulator) Merge(x *Accumulator) {
+	if x.count <= 0 {
+		return
+	}
+	if a.count <= 0 {
+		*a = *x
+		return
+	}
+	a.min, a.max = math.Min(a.min, x.min), math.Max(a.max, x.max)
+	weight := a.weight + x.weight
+	d := x.mean - a.mean
+	a.mean += d * x.weight / weight
+	a.m2 += x.m2 + d*d*a.weight*x.weight/weight
+	a.count += x.count
+	a.weight = weight
+	a.addSum(x.sum)
+	a.addSum(x.comp)
+}
+
+// Reset resets Accumulator to empty.
+func (a *Accumulator) Reset() {
+	*a = Accumulator{}
+}
+
+// Count returns number of accumulated values.
+func (a *Accumulator) Count() int64 {
+	return a.count
+}
+
+// Weight returns total weight of accumulated values.
+func (a *Accumulator) Weight() float64 {
+	return a.weight
+}
+
+// Sum returns the weighted sum of accumulated values.
+func (a *Accumulator) Sum() float64 {
+	if math.IsInf(a.sum, 0) || math.IsNaN(a.sum) {
+		return a.sum
+	}
+	return a.sum + a.comp
+}
+
+// Min returns the smallest accumulated value.
+// It returns -Inf if Accumulator is empty like Min.
+func (a *Accumulator) Min() float64 {
+	if a.count <= 0 {
+		return math.Inf(-1)
+	}
+	return a.min
+}
+
+// Max returns the largest accumulated value.
+// It returns +Inf if Accumulator is empty like Max.
+func (a *Accumulator) Max() float64 {
+	if a.count <= 0 {
+		return math.Inf(+1)
+	}
+	return a.max
+}
+
+// Mean returns the weighted arithmetic mean of accumulated values.
+// It returns 0 if Accumulator is empty like Avg.
+func (a *Accumulator) Mean() float64 {
+	return a.mean
+}
+
+// Variance returns the weighted population variance of accumulated values.
+// It returns NaN if Accumulator is empty like Variance.
+func (a *Accumulator) Variance() float64 {
+	if a.count <= 0 {
+		return math.NaN()
+	}
+	return a.m2 / a.weight
+}
+
+// SampleVariance returns the weighted sample variance of accumulated values, assuming the weights are frequencies.
+// It returns NaN if the total weight is not greater than 1 like SampleVariance.
+func (a *Accumulator) SampleVariance() float64 {
+	if !(a.weight > 1) {
+		return math.NaN()
+	}
+	return a.m2 / (a.weight - 1)
+}
+
+// StdDev returns the weighted population standard deviation of accumulated values.
+func (a *Accumulator) StdDev() float64 {
+	return math.Sqrt(a.Variance())
+}
+
+// SampleStdDev returns the weighted sample standard deviation of accumulated values.
+func (a *Accumulator) SampleStdDev() float64 {
+	return math.Sqrt(a.SampleVariance())
+}
+
+// accumulatorJSON is the JSON representation of Accumulator.
+type accumulatorJSON struct {
+	Count  int64            `json:"count"`
+	Weight accumulatorFloat `json:"weight"`
+	Sum    accumulatorFloat `json:"sum"`
+	Comp   accumulatorFloat `json:"comp"`
+	Min    accumulatorFloat `json:"min"`
+	Max    accumulatorFloat `json:"max"`
+	Mean   accumulatorFloat `json:"mean"`
+	M2     accumulatorFloat `json:"m2"`
+}
+
+// accumulatorFloat is a jsonFloat which is also encoded to the JSON string "NaN" for NaN, because NaN propagates in Accumulator.
+type accumulatorFloat float64
+
+func (x accumulatorFloat) MarshalJSON() ([]byte, error) {
+	if math.IsNaN(float64(x)) {
+		return json.Marshal("NaN")
+	}
+	return jsonFloat(x).MarshalJSON()
+}
+
+func (x *accumulatorFloat) UnmarshalJSON(data []byte) error {
+	return (*jsonFloat)(x).UnmarshalJSON(data)
+}
+
+// MarshalJSON is implementation of json.Marshaler.
+// The internal state is encoded exactly, and infinities and NaNs are encoded to strings.
+func (a *Accumulator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&accumulatorJSON{
+		Count:  a.count,
+		Weight: accumulatorFloat(a.weight),
+		Sum:    accumulatorFloat(a.sum),
+		Comp:   accumulatorFloat(a.comp),
+		Min:    accumulatorFloat(a.min),
+		Max:    accumulatorFloat(a.max),
+		Mean:   accumulatorFloat(a.mean),
+		M2:     accumulatorFloat(a.m2),
+	})
+}
+
+// UnmarshalJSON is implementation of json.Unmarshaler.
+func (a *Accumulator) UnmarshalJSON(data []byte) error {
+	var v accumulatorJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	return a.set(v.Count, []float64{float64(v.Weight), float64(v.Sum), float64(v.Comp), float64(v.Min), float64(v.Max), float64(v.Mean), float64(v.M2)})
+}
+
+// accumulatorBinaryVersion is the version of binary encoding of Accumulator.
+const accumulatorBinaryVersion = 1
+
+// MarshalBinary is implementation of encoding.BinaryMarshaler.
+// The data consists of a version byte, count and the float64 values of internal state in big endian.
+func (a *Accumulator) MarshalBinary() (data []byte, err error) {
+	data = make([]byte, 1+8*8)
+	data[0] = accumulatorBinaryVersion
+	binary.BigEndian.PutUint64(data[1:], uint64(a.count))
+	for i, f := range []float64{a.weight, a.sum, a.comp, a.min, a.max, a.mean, a.m2} {
+		binary.BigEndian.PutUint64(data[9+8*i:], math.Float64bits(f))
+	}
+	return data, nil
+}
+
+// UnmarshalBinary is implementation of encoding.BinaryUnmarshaler.
+func (a *Accumulator) UnmarshalBinary(data []byte) error {
+	if len(data) != 1+8*8 || data[0] != accumulatorBinaryVersion {
+		return ErrAccumulatorInvalidData
+	}
+	values := make([]float64, 7)
+	for i := range values {
+		values[i] = math.Float64frombits(binary.BigEndian.Uint64(data[9+8*i:]))
+	}
+	return a.set(int64(binary.BigEndian.Uint64(data[1:])), values)
+}
+
+// set sets the internal state by count and the values of weight, sum, comp, min, max, mean and m2 in this order.
+func (a *Accumulator) set(count int64, values []float64) error {
+	if count < 0 || !(values[0] >= 0) || (count == 0) != (values[0] == 0) {
+		return ErrAccumulatorInvalidData
+	}
+	*a = Accumulator{
+		count:  count,
+		weight: values[0],
+		sum:    values[1],
+		comp:   values[2],
+		min:    values[3],
+		max:    values[4],
+		mean:   values[5],
+		m2:     values[6],
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package xmath_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/goinsane/xmath"
+)
+
+func ExampleAccumulator() {
+	x := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	a := xmath.NewAccumulator()
+	for _, f := range x {
+		a.Add(f)
+	}
+	fmt.Println(a.Count(), a.Weight(), a.Sum(), a.Min(), a.Max(), a.Mean())
+	fmt.Println(a.Variance(), a.StdDev(), a.SampleVariance(), a.SampleStdDev())
+	fmt.Println(xmath.Variance(x...), xmath.SampleVariance(x...))
+
+	b := xmath.NewAccumulator()
+	b.AddWeighted(2, 1)
+	b.AddWeighted(4, 3)
+	b.AddWeighted(5, 2)
+	b.AddWeighted(7, 1)
+	b.AddWeighted(9, 1)
+	b.AddWeighted(100, 0)
+	fmt.Println(b.Count(), b.Weight(), b.Sum(), b.Min(), b.Max(), b.Mean(), b.Variance())
+
+	var c xmath.Accumulator
+	fmt.Println(c.Count(), c.Sum(), c.Min(), c.Max(), c.Mean(), c.Variance())
+	c.Add(1e100)
+	c.Add(1)
+	c.Add(-1e100)
+	c.Add(math.NaN())
+	fmt.Println(c.Count(), c.Sum(), c.Min(), c.Max(), c.Mean())
+
+	// Output:
+	// 8 8 40 2 9 5
+	// 4 2 4.571428571428571 2.138089935299395
+	// 4 4.571428571428571
+	// 5 8 40 2 9 5 4
+	// 0 0 -Inf +Inf 0 NaN
+	// 4 NaN NaN NaN NaN
+}
+
+func ExampleAccumulator_Merge() {
+	x := []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16, 1e9 + 1, 1e9 + 9}
+	var parts [3]xmath.Accumulator
+	for i, f := range x {
+		parts[i%3].Add(f)
+	}
+	var a xmath.Accumulator
+	for i := range parts {
+		a.Merge(&parts[i])
+	}
+	a.Merge(xmath.NewAccumulator())
+	fmt.Println(a.Count(), a.Sum(), a.Min(), a.Max())
+	fmt.Printf("%.12g %.12g\n", a.Mean(), a.Variance())
+	fmt.Printf("%.6g\n", xmath.Variance(x...))
+
+	// Output:
+	// 6 6.00000005e+09 1.000000001e+09 1.000000016e+09
+	// 1000000008.33 25.8888888889
+	// 25.8889
+}
+
+func ExampleAccumulator_MarshalJSON() {
+	var a xmath.Accumulator
+	for _, f := range []float64{0.1, 0.2, 0.3} {
+		a.Add(f)
+	}
+	data, err := json.Marshal(&a)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(data))
+	var b xmath.Accumulator
+	if err := json.Unmarshal(data, &b); err != nil {
+		panic(err)
+	}
+	fmt.Println(b == a)
+	a.Add(math.Inf(+1))
+	data, _ = json.Marshal(&a)
+	fmt.Println(string(data))
+	fmt.Println(json.Unmarshal([]byte(`{"count":-1}`), &b))
+
+	// Output:
+	// {"count":3,"weight":3,"sum":0.6000000000000001,"comp":-8.326672684688674e-17,"min":0.1,"max":0.3,"mean":0.2,"m2":0.01999999999999999}
+	// true
+	// {"count":4,"weight":4,"sum":"+Inf","comp":"NaN","min":0.1,"max":"+Inf","mean":"+Inf","m2":"NaN"}
+	// invalid accumulator data
+}
+
+func ExampleAccumulator_MarshalBinary() {
+	var a xmath.Accumulator
+	for _, f := range []float64{0.1, 0.2, 0.3} {
+		a.Add(f)
+	}
+	data, err := a.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(len(data))
+	var b xmath.Accumulator
+	fmt.Println(b.UnmarshalBinary(data), b == a)
+	fmt.Println(b.UnmarshalBinary(data[:10]))
+
+	// Output:
+	// 65
+	// <nil> true
+	// invalid accumulator data
+}
@@ -0,0 +1,353 @@
+package xmath
+
+import (
+	"math/bits"
+)
+
+// AddInt8 returns x+y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func AddInt8(x, y int8) (result int8, ok bool) {
+	return addSigned(x, y)
+}
+
+// AddInt16 returns x+y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func AddInt16(x, y int16) (result int16, ok bool) {
+	return addSigned(x, y)
+}
+
+// AddInt32 returns x+y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func AddInt32(x, y int32) (result int32, ok bool) {
+	return addSigned(x, y)
+}
+
+// AddInt64 returns x+y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func AddInt64(x, y int64) (result int64, ok bool) {
+	return addSigned(x, y)
+}
+
+// AddUint8 returns x+y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func AddUint8(x, y uint8) (result uint8, ok bool) {
+	return addUnsigned(x, y)
+}
+
+// AddUint16 returns x+y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func AddUint16(x, y uint16) (result uint16, ok bool) {
+	return addUnsigned(x, y)
+}
+
+// AddUint32 returns x+y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func AddUint32(x, y uint32) (result uint32, ok bool) {
+	return addUnsigned(x, y)
+}
+
+// AddUint64 returns x+y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func AddUint64(x, y uint64) (result uint64, ok bool) {
+	return addUnsigned(x, y)
+}
+
+// SubInt8 returns x-y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func SubInt8(x, y int8) (result int8, ok bool) {
+	return subSigned(x, y)
+}
+
+// SubInt16 returns x-y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func SubInt16(x, y int16) (result int16, ok bool) {
+	return subSigned(x, y)
+}
+
+// SubInt32 returns x-y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func SubInt32(x, y int32) (result int32, ok bool) {
+	return subSigned(x, y)
+}
+
+// SubInt64 returns x-y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func SubInt64(x, y int64) (result int64, ok bool) {
+	return subSigned(x, y)
+}
+
+// SubUint8 returns x-y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func SubUint8(x, y uint8) (result uint8, ok bool) {
+	return subUnsigned(x, y)
+}
+
+// SubUint16 returns x-y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func SubUint16(x, y uint16) (result uint16, ok bool) {
+	return subUnsigned(x, y)
+}
+
+// SubUint32 returns x-y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func SubUint32(x, y uint32) (result uint32, ok bool) {
+	return subUnsigned(x, y)
+}
+
+// SubUint64 returns x-y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func SubUint64(x, y uint64) (result uint64, ok bool) {
+	return subUnsigned(x, y)
+}
+
+// MulInt8 returns x*y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func MulInt8(x, y int8) (result int8, ok bool) {
+	return mulSigned(x, y)
+}
+
+// MulInt16 returns x*y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func MulInt16(x, y int16) (result int16, ok bool) {
+	return mulSigned(x, y)
+}
+
+// MulInt32 returns x*y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func MulInt32(x, y int32) (result int32, ok bool) {
+	return mulSigned(x, y)
+}
+
+// MulInt64 returns x*y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func MulInt64(x, y int64) (result int64, ok bool) {
+	return mulSigned(x, y)
+}
+
+// MulUint8 returns x*y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func MulUint8(x, y uint8) (result uint8, ok bool) {
+	return mulUnsigned(x, y)
+}
+
+// MulUint16 returns x*y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func MulUint16(x, y uint16) (result uint16, ok bool) {
+	return mulUnsigned(x, y)
+}
+
+// MulUint32 returns x*y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func MulUint32(x, y uint32) (result uint32, ok bool) {
+	return mulUnsigned(x, y)
+}
+
+// MulUint64 returns x*y.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func MulUint64(x, y uint64) (result uint64, ok bool) {
+	return mulUnsigned(x, y)
+}
+
+// DivInt8 returns x/y.
+// If y is 0 or the result overflows, it returns ok is false. The result is 0 for division by zero, and x for MinInt8 / -1.
+func DivInt8(x, y int8) (result int8, ok bool) {
+	return divSigned(x, y)
+}
+
+// DivInt16 returns x/y.
+// If y is 0 or the result overflows, it returns ok is false. The result is 0 for division by zero, and x for MinInt16 / -1.
+func DivInt16(x, y int16) (result int16, ok bool) {
+	return divSigned(x, y)
+}
+
+// DivInt32 returns x/y.
+// If y is 0 or the result overflows, it returns ok is false. The result is 0 for division by zero, and x for MinInt32 / -1.
+func DivInt32(x, y int32) (result int32, ok bool) {
+	return divSigned(x, y)
+}
+
+// DivInt64 returns x/y.
+// If y is 0 or the result overflows, it returns ok is false. The result is 0 for division by zero, and x for MinInt64 / -1.
+func DivInt64(x, y int64) (result int64, ok bool) {
+	return divSigned(x, y)
+}
+
+// DivUint8 returns x/y.
+// If y is 0 or the result overflows, it returns ok is false. The result is 0 for division by zero.
+func DivUint8(x, y uint8) (result uint8, ok bool) {
+	return divUnsigned(x, y)
+}
+
+// DivUint16 returns x/y.
+// If y is 0 or the result overflows, it returns ok is false. The result is 0 for division by zero.
+func DivUint16(x, y uint16) (result uint16, ok bool) {
+	return divUnsigned(x, y)
+}
+
+// DivUint32 returns x/y.
+// If y is 0 or the result overflows, it returns ok is false. The result is 0 for division by zero.
+func DivUint32(x, y uint32) (result uint32, ok bool) {
+	return divUnsigned(x, y)
+}
+
+// DivUint64 returns x/y.
+// If y is 0 or the result overflows, it returns ok is false. The result is 0 for division by zero.
+func DivUint64(x, y uint64) (result uint64, ok bool) {
+	return divUnsigned(x, y)
+}
+
+// NegInt8 returns -x.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func NegInt8(x int8) (result int8, ok bool) {
+	return negSigned(x)
+}
+
+// NegInt16 returns -x.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func NegInt16(x int16) (result int16, ok bool) {
+	return negSigned(x)
+}
+
+// NegInt32 returns -x.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func NegInt32(x int32) (result int32, ok bool) {
+	return negSigned(x)
+}
+
+// NegInt64 returns -x.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func NegInt64(x int64) (result int64, ok bool) {
+	return negSigned(x)
+}
+
+// NegUint8 returns -x.
+// It returns ok is false unless x is 0, and the result is wrapped around.
+func NegUint8(x uint8) (result uint8, ok bool) {
+	return negUnsigned(x)
+}
+
+// NegUint16 returns -x.
+// It returns ok is false unless x is 0, and the result is wrapped around.
+func NegUint16(x uint16) (result uint16, ok bool) {
+	return negUnsigned(x)
+}
+
+// NegUint32 returns -x.
+// It returns ok is false unless x is 0, and the result is wrapped around.
+func NegUint32(x uint32) (result uint32, ok bool) {
+	return negUnsigned(x)
+}
+
+// NegUint64 returns -x.
+// It returns ok is false unless x is 0, and the result is wrapped around.
+func NegUint64(x uint64) (result uint64, ok bool) {
+	return negUnsigned(x)
+}
+
+// AbsInt8 returns the absolute value of x.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func AbsInt8(x int8) (result int8, ok bool) {
+	return absSigned(x)
+}
+
+// AbsInt16 returns the absolute value of x.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func AbsInt16(x int16) (result int16, ok bool) {
+	return absSigned(x)
+}
+
+// AbsInt32 returns the absolute value of x.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func AbsInt32(x int32) (result int32, ok bool) {
+	return absSigned(x)
+}
+
+// AbsInt64 returns the absolute value of x.
+// If the result overflows, it returns ok is false and the result is wrapped around.
+func AbsInt64(x int64) (result int64, ok bool) {
+	return absSigned(x)
+}
+
+// addSigned returns x+y and checks overflow by the signs of the operands and the result.
+func addSigned[T Signed](x, y T) (T, bool) {
+	z := x + y
+	return z, (x >= 0) != (y >= 0) || (z >= 0) == (x >= 0)
+}
+
+// subSigned returns x-y and checks overflow by the signs of the operands and the result.
+func subSigned[T Signed](x, y T) (T, bool) {
+	z := x - y
+	return z, (x >= 0) == (y >= 0) || (z >= 0) == (x >= 0)
+}
+
+// mulSigned returns x*y by multiplying the magnitudes in 128 bits.
+// The result doesn't overflow only if its magnitude and sign are same with the exact product.
+func mulSigned[T Signed](x, y T) (T, bool) {
+	hi, lo := bits.Mul64(absUint64(int64(x)), absUint64(int64(y)))
+	z := x * y
+	neg := (x < 0) != (y < 0) && lo != 0
+	return z, hi == 0 && absUint64(int64(z)) == lo && (z < 0) == neg
+}
+
+// divSigned returns x/y. Min/-1 is the only overflowing division of signed integers.
+func divSigned[T Signed](x, y T) (T, bool) {
+	if y == 0 {
+		return 0, false
+	}
+	if y == -1 && x < 0 && -x < 0 {
+		return x, false
+	}
+	return x / y, true
+}
+
+// negSigned returns -x. Min is the only value that can't be negated, and the only non-zero value which is same with its negation.
+func negSigned[T Signed](x T) (T, bool) {
+	return -x, x != -x || x == 0
+}
+
+// absSigned returns the absolute value of x.
+func absSigned[T Signed](x T) (T, bool) {
+	if x < 0 {
+		return negSigned(x)
+	}
+	return x, true
+}
+
+// absUint64 returns the absolute value of x as uint64, which is exact also for MinInt64.
+func absUint64(x int64) uint64 {
+	if x < 0 {
+		return -uint64(x)
+	}
+	return uint64(x)
+}
+
+// addUnsigned returns x+y by the carry of 64-bit addition, and checks the range of T.
+func addUnsigned[T Unsigned](x, y T) (T, bool) {
+	z, carry := bits.Add64(uint64(x), uint64(y), 0)
+	return T(z), carry == 0 && uint64(T(z)) == z
+}
+
+// subUnsigned returns x-y by the borrow of 64-bit subtraction.
+func subUnsigned[T Unsigned](x, y T) (T, bool) {
+	_, borrow := bits.Sub64(uint64(x), uint64(y), 0)
+	return x - y, borrow == 0
+}
+
+// mulUnsigned returns x*y by 128-bit multiplication, and checks the range of T.
+func mulUnsigned[T Unsigned](x, y T) (T, bool) {
+	hi, lo := bits.Mul64(uint64(x), uint64(y))
+	return T(lo), hi == 0 && uint64(T(lo)) == lo
+}
+
+// divUnsigned returns x/y. Unsigned division overflows never.
+func divUnsigned[T Unsigned](x, y T) (T, bool) {
+	if y == 0 {
+		return 0, false
+	}
+	return x / y, true
+}
+
+// negUnsigned returns -x. 0 is the only value that can be negated in unsigned integers.
+func negUnsigned[T Unsigned](x T) (T, bool) {
+	return -x, x == 0
+}
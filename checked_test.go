@@ -0,0 +1,120 @@
+package xmath_test
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/goinsane/xmath"
+)
+
+func ExampleAddInt64() {
+	fmt.Println(xmath.AddInt64(1, 2))
+	fmt.Println(xmath.AddInt64(math.MaxInt64, 1))
+	fmt.Println(xmath.AddInt64(math.MinInt64, -1))
+	fmt.Println(xmath.AddInt64(math.MaxInt64, math.MinInt64))
+	fmt.Println(xmath.AddInt8(100, 27))
+	fmt.Println(xmath.AddInt8(100, 28))
+	fmt.Println(xmath.AddUint64(math.MaxUint64, 1))
+	fmt.Println(xmath.AddUint16(65535, 0))
+
+	// Output:
+	// 3 true
+	// -9223372036854775808 false
+	// 9223372036854775807 false
+	// -1 true
+	// 127 true
+	// -128 false
+	// 0 false
+	// 65535 true
+}
+
+func ExampleSubInt64() {
+	fmt.Println(xmath.SubInt64(1, 2))
+	fmt.Println(xmath.SubInt64(math.MinInt64, 1))
+	fmt.Println(xmath.SubInt64(0, math.MinInt64))
+	fmt.Println(xmath.SubInt64(-1, math.MinInt64))
+	fmt.Println(xmath.SubUint64(1, 2))
+	fmt.Println(xmath.SubUint32(2, 1))
+
+	// Output:
+	// -1 true
+	// 9223372036854775807 false
+	// -9223372036854775808 false
+	// 9223372036854775807 true
+	// 18446744073709551615 false
+	// 1 true
+}
+
+func ExampleMulInt64() {
+	fmt.Println(xmath.MulInt64(3, -4))
+	fmt.Println(xmath.MulInt64(math.MinInt64, 1))
+	fmt.Println(xmath.MulInt64(math.MinInt64, -1))
+	fmt.Println(xmath.MulInt64(-1<<32, 1<<31))
+	fmt.Println(xmath.MulInt64(1<<32, 1<<31))
+	fmt.Println(xmath.MulInt64(math.MaxInt64, 2))
+	fmt.Println(xmath.MulInt32(-1<<16, 1<<15))
+	fmt.Println(xmath.MulInt32(1<<16, 1<<15))
+	fmt.Println(xmath.MulUint64(1<<32, 1<<32))
+	fmt.Println(xmath.MulUint64(math.MaxUint32, math.MaxUint32+2))
+	fmt.Println(xmath.MulUint8(16, 16))
+
+	// Output:
+	// -12 true
+	// -9223372036854775808 true
+	// -9223372036854775808 false
+	// -9223372036854775808 true
+	// -9223372036854775808 false
+	// -2 false
+	// -2147483648 true
+	// -2147483648 false
+	// 0 false
+	// 18446744073709551615 true
+	// 0 false
+}
+
+func ExampleDivInt64() {
+	fmt.Println(xmath.DivInt64(7, -2))
+	fmt.Println(xmath.DivInt64(math.MinInt64, -1))
+	fmt.Println(xmath.DivInt64(math.MinInt64, 1))
+	fmt.Println(xmath.DivInt64(1, 0))
+	fmt.Println(xmath.DivInt8(-128, -1))
+	fmt.Println(xmath.DivUint64(7, 2))
+	fmt.Println(xmath.DivUint64(7, 0))
+
+	// Output:
+	// -3 true
+	// -9223372036854775808 false
+	// -9223372036854775808 true
+	// 0 false
+	// -128 false
+	// 3 true
+	// 0 false
+}
+
+func ExampleNegInt64() {
+	fmt.Println(xmath.NegInt64(5))
+	fmt.Println(xmath.NegInt64(math.MinInt64))
+	fmt.Println(xmath.NegInt64(math.MaxInt64))
+	fmt.Println(xmath.NegUint64(0))
+	fmt.Println(xmath.NegUint64(1))
+
+	// Output:
+	// -5 true
+	// -9223372036854775808 false
+	// -9223372036854775807 true
+	// 0 true
+	// 18446744073709551615 false
+}
+
+func ExampleAbsInt64() {
+	fmt.Println(xmath.AbsInt64(-5))
+	fmt.Println(xmath.AbsInt64(math.MinInt64))
+	fmt.Println(xmath.AbsInt64(math.MinInt64 + 1))
+	fmt.Println(xmath.AbsInt16(math.MinInt16))
+
+	// Output:
+	// 5 true
+	// -9223372036854775808 false
+	// 9223372036854775807 true
+	// -32768 false
+}
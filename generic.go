@@ -0,0 +1,217 @@
+package xmath
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Signed is a constraint that permits any signed integer type.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is a constraint that permits any unsigned integer type.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is a constraint that permits any integer type.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float is a constraint that permits any floating point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is a constraint that permits any integer or floating point type.
+type Number interface {
+	Integer | Float
+}
+
+// MaxOf returns the larger of x...
+// For floating point types, it's similar with Max.
+// For integer types, it returns the max value of the type if x is empty like MaxInt and MaxUint.
+func MaxOf[T Number](x ...T) T {
+	if len(x) <= 0 {
+		return maxValue[T]()
+	}
+	result := minValue[T]()
+	if isFloat[T]() {
+		for _, a := range x {
+			result = T(math.Max(float64(a), float64(result)))
+		}
+		return result
+	}
+	for _, a := range x {
+		if a > result {
+			result = a
+		}
+	}
+	return result
+}
+
+// MinOf returns the smaller of x...
+// For floating point types, it's similar with Min.
+// For integer types, it returns the min value of the type if x is empty like MinInt and MinUint.
+func MinOf[T Number](x ...T) T {
+	if len(x) <= 0 {
+		return minValue[T]()
+	}
+	result := maxValue[T]()
+	if isFloat[T]() {
+		for _, a := range x {
+			result = T(math.Min(float64(a), float64(result)))
+		}
+		return result
+	}
+	for _, a := range x {
+		if a < result {
+			result = a
+		}
+	}
+	return result
+}
+
+// MaxMinOf returns the max, min values in this order, similar with MaxOf and MinOf functions.
+func MaxMinOf[T Number](x ...T) (max T, min T) {
+	min, max = MinMaxOf(x...)
+	return
+}
+
+// MinMaxOf returns the min, max values in this order, similar with MinOf and MaxOf functions.
+func MinMaxOf[T Number](x ...T) (min T, max T) {
+	if len(x) <= 0 {
+		return minValue[T](), maxValue[T]()
+	}
+	min, max = maxValue[T](), minValue[T]()
+	if isFloat[T]() {
+		for _, a := range x {
+			min = T(math.Min(float64(a), float64(min)))
+			max = T(math.Max(float64(a), float64(max)))
+		}
+		return
+	}
+	for _, a := range x {
+		if a < min {
+			min = a
+		}
+		if a > max {
+			max = a
+		}
+	}
+	return
+}
+
+// SumOf returns the floating point of sum of x...
+func SumOf[T Number](x ...T) (sum float64) {
+	for _, y := range x {
+		sum += float64(y)
+	}
+	return
+}
+
+// AvgOf returns the floating point of arithmetic mean of x...
+// The floating point values of x are summed by Neumaier's compensated summation, and the remainder of the division is compensated too.
+// The values of integer types are converted to float64 before summing, so the result isn't exact beyond 2^53.
+// If the sum overflows, x is scaled by a power of 2 to compute the mean without overflow.
+func AvgOf[T Number](x ...T) (avg float64) {
+	if len(x) <= 0 {
+		return 0
+	}
+	exp := 0
+	sum, c := neumaierSum(x, 1)
+	if math.IsInf(sum, 0) || math.IsNaN(sum) {
+		exp = bits.Len(uint(len(x)))
+		sum, c = neumaierSum(x, math.Ldexp(1, -exp))
+		if math.IsInf(sum, 0) || math.IsNaN(sum) {
+			return sum
+		}
+	}
+	n := float64(len(x))
+	q := sum / n
+	r := math.FMA(-q, n, sum)
+	return math.Ldexp(q+(r+c)/n, exp)
+}
+
+// SumIntOf returns the sum of x... in the integer type of x.
+// If the result overflows, it returns overflow is true.
+func SumIntOf[T Integer](x ...T) (sum T, overflow bool) {
+	for _, y := range x {
+		last := sum
+		sum += y
+		if !overflow && ((y > 0 && sum < last) || (y < 0 && sum > last)) {
+			overflow = true
+		}
+	}
+	return
+}
+
+// AvgIntOf returns the arithmetic mean of x... in the integer type of x.
+// If the sum overflows, it returns overflow is true.
+func AvgIntOf[T Integer](x ...T) (avg T, overflow bool) {
+	var sum T
+	sum, overflow = SumIntOf(x...)
+	if count := len(x); count > 0 {
+		if k := T(count); int(k) == count && k > 0 {
+			avg = sum / k
+		}
+	}
+	return
+}
+
+// isFloat checks T is a floating point type.
+func isFloat[T Number]() bool {
+	h := 0.5
+	return T(h) != 0
+}
+
+// isFloat32 checks T is a 32-bit floating point type.
+func isFloat32[T Number]() bool {
+	h := 1 + 0x1p-30
+	return isFloat[T]() && T(h) == 1
+}
+
+// isSigned checks T is a signed integer or floating point type.
+func isSigned[T Number]() bool {
+	var z T
+	return z-1 < 0
+}
+
+// intBits returns the size of integer type T in bits.
+func intBits[T Number]() int {
+	one := uint64(1)
+	switch {
+	case T(one<<8) == 0:
+		return 8
+	case T(one<<16) == 0:
+		return 16
+	case T(one<<32) == 0:
+		return 32
+	}
+	return 64
+}
+
+// maxValue returns the max value of T, or +Inf for floating point types.
+func maxValue[T Number]() T {
+	if isFloat[T]() {
+		return T(math.Inf(+1))
+	}
+	var z T
+	if !isSigned[T]() {
+		return z - 1
+	}
+	return T(uint64(1)<<(intBits[T]()-1) - 1)
+}
+
+// minValue returns the min value of T, or -Inf for floating point types.
+func minValue[T Number]() T {
+	if isFloat[T]() {
+		return T(math.Inf(-1))
+	}
+	if !isSigned[T]() {
+		return 0
+	}
+	return T(int64(-1) << (intBits[T]() - 1))
+}
@@ -0,0 +1,75 @@
+package xmath_test
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/goinsane/xmath"
+)
+
+type celsius float32
+
+func ExampleMaxOf() {
+	fmt.Println(xmath.MaxOf[int8](3, -7, 5), xmath.MaxOf[int8]())
+	fmt.Println(xmath.MaxOf[uint8](3, 7, 5), xmath.MaxOf[uint8]())
+	fmt.Println(xmath.MaxOf[float32](3, -7, 5), xmath.MaxOf[float32](3, float32(math.NaN()), 5), xmath.MaxOf[float32]())
+	fmt.Println(xmath.MaxOf[celsius](21.5, 19, 23.25))
+
+	// Output:
+	// 5 127
+	// 7 255
+	// 5 NaN +Inf
+	// 23.25
+}
+
+func ExampleMinOf() {
+	fmt.Println(xmath.MinOf[int16](3, -7, 5), xmath.MinOf[int16]())
+	fmt.Println(xmath.MinOf[uint32](3, 7, 5), xmath.MinOf[uint32]())
+	fmt.Println(xmath.MinOf[float32](3, -7, 5), xmath.MinOf[float32](3, float32(math.NaN()), 5), xmath.MinOf[float32]())
+	fmt.Println(xmath.MinOf(float32(math.Copysign(0, -1)), 0))
+
+	// Output:
+	// -7 -32768
+	// 3 0
+	// -7 NaN -Inf
+	// -0
+}
+
+func ExampleMinMaxOf() {
+	fmt.Println(xmath.MinMaxOf[int32](3, -7, 5))
+	fmt.Println(xmath.MinMaxOf[int32]())
+	fmt.Println(xmath.MaxMinOf[float32](3, -7, 5))
+	fmt.Println(xmath.MaxMinOf[uint64]())
+
+	// Output:
+	// -7 5
+	// -2147483648 2147483647
+	// 5 -7
+	// 18446744073709551615 0
+}
+
+func ExampleSumOf() {
+	fmt.Println(xmath.SumOf[int8](100, 100, 100), xmath.AvgOf[int8](100, 100, 101))
+	fmt.Println(xmath.SumOf[float32](0.5, 0.25), xmath.AvgOf[float32](0.5, 0.25))
+
+	// Output:
+	// 300 100.33333333333333
+	// 0.75 0.375
+}
+
+func ExampleSumIntOf() {
+	fmt.Println(xmath.SumIntOf[int8](100, 27))
+	fmt.Println(xmath.SumIntOf[int8](100, 28, -100))
+	fmt.Println(xmath.SumIntOf[uint8](200, 55))
+	fmt.Println(xmath.SumIntOf[uint8](200, 56))
+	fmt.Println(xmath.AvgIntOf[int8](100, 20, -30))
+	fmt.Println(xmath.AvgIntOf[int8](make([]int8, 200)...))
+
+	// Output:
+	// 127 false
+	// 28 true
+	// 255 false
+	// 0 true
+	// 30 false
+	// 0 false
+}
@@ -1,3 +1,3 @@
 module github.com/goinsane/xmath
 
-go 1.5
+go 1.18
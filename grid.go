@@ -0,0 +1,212 @@
+package xmath
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrGridDimensionMismatch = errors.New("dimension mismatch")
+)
+
+// Grid is a utility to quantize multi-dimensional points by one Scale per axis.
+// The points of Grid are indexed in row-major order, so the index of the last axis varies fastest.
+type Grid struct {
+	axes   []Scale
+	counts []int64
+	count  int64
+}
+
+// NewGrid returns a new Grid with given axes.
+// All of axes must have finite range, and the total count of points must fit in int64.
+func NewGrid(axes ...Scale) (g *Grid, err error) {
+	if len(axes) <= 0 {
+		return nil, ErrGridDimensionMismatch
+	}
+	g = &Grid{
+		axes:   make([]Scale, 0, len(axes)),
+		counts: make([]int64, 0, len(axes)),
+		count:  1,
+	}
+	for _, axis := range axes {
+		count := axis.Count64()
+		if count <= 0 {
+			return nil, ErrStepperRangeOverflow
+		}
+		if g.count > math.MaxInt64/count {
+			return nil, ErrStepperRangeOverflow
+		}
+		g.axes = append(g.axes, axis)
+		g.counts = append(g.counts, count)
+		g.count *= count
+	}
+	return g, nil
+}
+
+// Dim returns number of axes of Grid.
+func (g *Grid) Dim() int {
+	return len(g.axes)
+}
+
+// Axis returns the Scale of the axis i.
+func (g *Grid) Axis(i int) Scale {
+	return g.axes[i]
+}
+
+// Count is same with Count64 if count is less than or equal to MaxIntValue.
+// If count is greater than MaxIntValue, it returns MaxIntValue.
+func (g *Grid) Count() int {
+	if g.count > MaxIntValue {
+		return MaxIntValue
+	}
+	return int(g.count)
+}
+
+// Count64 returns total number of points of Grid.
+func (g *Grid) Count64() int64 {
+	return g.count
+}
+
+// Indexes returns the indexes of axes by given flat index.
+func (g *Grid) Indexes(index int64) ([]int64, error) {
+	if index >= g.count {
+		return nil, ErrStepperMaxExceeded
+	}
+	if index < 0 {
+		return nil, ErrStepperMinExceeded
+	}
+	indexes := make([]int64, len(g.axes))
+	for i := len(g.axes) - 1; i >= 0; i-- {
+		indexes[i] = index % g.counts[i]
+		index /= g.counts[i]
+	}
+	return indexes, nil
+}
+
+// FlatIndex returns the flat index by given indexes of axes.
+func (g *Grid) FlatIndex(indexes []int64) (int64, error) {
+	if len(indexes) != len(g.axes) {
+		return 0, ErrGridDimensionMismatch
+	}
+	var index int64
+	for i, k := range indexes {
+		if k >= g.counts[i] {
+			return 0, ErrStepperMaxExceeded
+		}
+		if k < 0 {
+			return 0, ErrStepperMinExceeded
+		}
+		index = index*g.counts[i] + k
+	}
+	return index, nil
+}
+
+// Point returns the point by given flat index.
+func (g *Grid) Point(index int64) ([]float64, error) {
+	indexes, err := g.Indexes(index)
+	if err != nil {
+		return nil, err
+	}
+	point := make([]float64, len(indexes))
+	for i, k := range indexes {
+		point[i], err = g.axes[i].Step64(k)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return point, nil
+}
+
+// Index returns the flat index of the point which given point is normalized to.
+// If any coordinate is out of range, it returns the flat index of the point clamped to the range with the first error.
+func (g *Grid) Index(point []float64) (int64, error) {
+	if len(point) != len(g.axes) {
+		return 0, ErrGridDimensionMismatch
+	}
+	var index int64
+	var err error
+	for i, f := range point {
+		k, e := g.axes[i].Index(f)
+		if e != nil && err == nil {
+			err = e
+		}
+		index = index*g.counts[i] + k
+	}
+	return index, err
+}
+
+// Normalize returns the normalized point of given point by normalizing all coordinates.
+// If any coordinate is out of range, it returns the first error.
+func (g *Grid) Normalize(point []float64) ([]float64, error) {
+	if len(point) != len(g.axes) {
+		return nil, ErrGridDimensionMismatch
+	}
+	result := make([]float64, len(point))
+	var err error
+	for i, f := range point {
+		var e error
+		result[i], e = g.axes[i].Normalize(f)
+		if e != nil && err == nil {
+			err = e
+		}
+	}
+	return result, err
+}
+
+// Neighbors returns the flat indexes of the neighbors of the point by given flat index in ascending order.
+// If diagonal is false, neighbors differ by one step on only one axis. Otherwise, they differ by at most one step on all axes.
+func (g *Grid) Neighbors(index int64, diagonal bool) ([]int64, error) {
+	indexes, err := g.Indexes(index)
+	if err != nil {
+		return nil, err
+	}
+	var result []int64
+	if !diagonal {
+		stride := g.count
+		for i, k := range indexes {
+			stride /= g.counts[i]
+			if k > 0 {
+				result = append(result, index-stride)
+			}
+		}
+		stride = 1
+		for i := len(indexes) - 1; i >= 0; i-- {
+			if indexes[i] < g.counts[i]-1 {
+				result = append(result, index+stride)
+			}
+			stride *= g.counts[i]
+		}
+		return result, nil
+	}
+	offsets := make([]int64, len(indexes))
+	for i := range offsets {
+		offsets[i] = -1
+	}
+	for {
+		var n int64
+		valid, zero := true, true
+		for i, k := range indexes {
+			m := k + offsets[i]
+			if m < 0 || m >= g.counts[i] {
+				valid = false
+				break
+			}
+			if offsets[i] != 0 {
+				zero = false
+			}
+			n = n*g.counts[i] + m
+		}
+		if valid && !zero {
+			result = append(result, n)
+		}
+		i := len(offsets) - 1
+		for ; i >= 0 && offsets[i] == 1; i-- {
+			offsets[i] = -1
+		}
+		if i < 0 {
+			break
+		}
+		offsets[i]++
+	}
+	return result, nil
+}
@@ -0,0 +1,105 @@
+package xmath_test
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/goinsane/xmath"
+)
+
+func newGrid() *xmath.Grid {
+	price, err := xmath.NewStepper(2, 10, 0.25, 2, 1)
+	if err != nil {
+		panic(err)
+	}
+	size, err := xmath.NewStepper(0, 10, 10, 30, 10)
+	if err != nil {
+		panic(err)
+	}
+	g, err := xmath.NewGrid(price, size)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+func ExampleNewGrid() {
+	s, err := xmath.NewStepper(0, 10, 1, math.MaxInt32, 0)
+	if err != nil {
+		panic(err)
+	}
+	g, err := xmath.NewGrid(s, s)
+	fmt.Println(g.Count64(), err)
+	_, err = xmath.NewGrid(s, s, s)
+	fmt.Println(err)
+	_, err = xmath.NewGrid()
+	fmt.Println(err)
+
+	// Output:
+	// 4611686018427387904 <nil>
+	// range overflow
+	// dimension mismatch
+}
+
+func ExampleGrid_Point() {
+	g := newGrid()
+	fmt.Println(g.Dim(), g.Count())
+	for i := 0; i < g.Count(); i++ {
+		p, _ := g.Point(int64(i))
+		fmt.Println(i, p)
+	}
+	fmt.Println(g.Point(int64(g.Count())))
+
+	// Output:
+	// 2 15
+	// 0 [1 10]
+	// 1 [1 20]
+	// 2 [1 30]
+	// 3 [1.25 10]
+	// 4 [1.25 20]
+	// 5 [1.25 30]
+	// 6 [1.5 10]
+	// 7 [1.5 20]
+	// 8 [1.5 30]
+	// 9 [1.75 10]
+	// 10 [1.75 20]
+	// 11 [1.75 30]
+	// 12 [2 10]
+	// 13 [2 20]
+	// 14 [2 30]
+	// [] max exceeded
+}
+
+func ExampleGrid_Index() {
+	g := newGrid()
+	fmt.Println(g.Index([]float64{1.3, 24}))
+	fmt.Println(g.Normalize([]float64{1.3, 24}))
+	fmt.Println(g.Index([]float64{2.2, 5}))
+	fmt.Println(g.Normalize([]float64{2.2, 5}))
+	fmt.Println(g.Index([]float64{1.3}))
+	fmt.Println(g.FlatIndex([]int64{3, 1}))
+	fmt.Println(g.Indexes(10))
+
+	// Output:
+	// 4 <nil>
+	// [1.25 20] <nil>
+	// 12 max exceeded
+	// [2 10] max exceeded
+	// 0 dimension mismatch
+	// 10 <nil>
+	// [3 1] <nil>
+}
+
+func ExampleGrid_Neighbors() {
+	g := newGrid()
+	fmt.Println(g.Neighbors(4, false))
+	fmt.Println(g.Neighbors(4, true))
+	fmt.Println(g.Neighbors(0, false))
+	fmt.Println(g.Neighbors(14, true))
+
+	// Output:
+	// [1 3 5 7] <nil>
+	// [0 1 2 3 5 6 7 8] <nil>
+	// [1 3] <nil>
+	// [10 11 13] <nil>
+}
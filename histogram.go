@@ -0,0 +1,172 @@
+package xmath
+
+import (
+	"math"
+)
+
+// MaxHistogramCount is the max number of bins of Histogram.
+const MaxHistogramCount = 1 << 20
+
+// Histogram is a utility to count weighted values into the bins of a Scale.
+// Each value is counted in the bin of the step which it's normalized to.
+// The values out of range are counted in the underflow and overflow buckets, and NaN values are ignored.
+// Histogram isn't safe for concurrent use.
+type Histogram struct {
+	scale     Scale
+	steps     []float64
+	bins      []float64
+	underflow float64
+	overflow  float64
+}
+
+// NewHistogram returns a new Histogram with given scale.
+// The scale must have finite range.
+// It returns StepperError with ErrStepperRangeOverflow if the count of scale is greater than MaxHistogramCount.
+func NewHistogram(scale Scale) (h *Histogram, err error) {
+	count := scale.Count64()
+	if count <= 0 {
+		return nil, ErrStepperRangeOverflow
+	}
+	if count > MaxHistogramCount {
+		return nil, newStepperError("count", float64(count), ErrStepperRangeOverflow)
+	}
+	h = &Histogram{
+		scale: scale,
+		steps: make([]float64, count),
+		bins:  make([]float64, count),
+	}
+	for i := range h.steps {
+		h.steps[i], err = scale.Step64(int64(i))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// Scale returns the Scale of Histogram.
+func (h *Histogram) Scale() Scale {
+	return h.scale
+}
+
+// Add is synonym with AddWeighted(f, 1).
+func (h *Histogram) Add(f float64) {
+	h.AddWeighted(f, 1)
+}
+
+// AddWeighted counts f with weight w.
+func (h *Histogram) AddWeighted(f float64, w float64) {
+	index, err := h.scale.Index(f)
+	switch err {
+	case nil:
+		h.bins[index] += w
+	case ErrStepperMaxExceeded:
+		h.overflow += w
+	case ErrStepperMinExceeded:
+		h.underflow += w
+	}
+}
+
+// Bin returns the count of the bin by given index.
+func (h *Histogram) Bin(index int) float64 {
+	return h.bins[index]
+}
+
+// Bins returns a copy of counts of all bins.
+func (h *Histogram) Bins() []float64 {
+	result := make([]float64, len(h.bins))
+	copy(result, h.bins)
+	return result
+}
+
+// Underflow returns the count of values which are less than the range.
+func (h *Histogram) Underflow() float64 {
+	return h.underflow
+}
+
+// Overflow returns the count of values which are greater than the range.
+func (h *Histogram) Overflow() float64 {
+	return h.overflow
+}
+
+// Total returns the total count including underflow and overflow.
+func (h *Histogram) Total() float64 {
+	return h.underflow + Sum(h.bins...) + h.overflow
+}
+
+// Cumulative returns cumulative counts of bins.
+// The count of bin i in the result is the sum of underflow and counts of bins up to i.
+func (h *Histogram) Cumulative() []float64 {
+	result := make([]float64, len(h.bins))
+	sum := h.underflow
+	for i, c := range h.bins {
+		sum += c
+		result[i] = sum
+	}
+	return result
+}
+
+// Merge adds the counts of x to Histogram.
+// It returns ErrStepperIncompatible unless both of histograms have same steps.
+func (h *Histogram) Merge(x *Histogram) error {
+	if len(h.steps) != len(x.steps) {
+		return ErrStepperIncompatible
+	}
+	for i := range h.steps {
+		if h.steps[i] != x.steps[i] {
+			return ErrStepperIncompatible
+		}
+	}
+	for i := range h.bins {
+		h.bins[i] += x.bins[i]
+	}
+	h.underflow += x.underflow
+	h.overflow += x.overflow
+	return nil
+}
+
+// Quantile returns the estimated q-quantile of counted values.
+// Each bin is assumed to spread uniformly between the midpoints to its neighbour steps.
+//
+// Special cases are:
+//	Quantile(q) = NaN if q is out of [0, 1] or the total count is 0
+//	Quantile(q) = -Inf if the quantile is in underflow
+//	Quantile(q) = +Inf if the quantile is in overflow
+func (h *Histogram) Quantile(q float64) float64 {
+	total := h.Total()
+	if !(0 <= q && q <= 1) || !(total > 0) {
+		return math.NaN()
+	}
+	target := q * total
+	sum := h.underflow
+	if target < sum {
+		return math.Inf(-1)
+	}
+	for i, c := range h.bins {
+		if c > 0 && target <= sum+c {
+			lo, hi := h.edges(i)
+			return lo + (target-sum)/c*(hi-lo)
+		}
+		sum += c
+	}
+	return math.Inf(+1)
+}
+
+// edges returns the lower and upper edges of the bin i.
+func (h *Histogram) edges(i int) (lo float64, hi float64) {
+	n := len(h.steps)
+	if n <= 1 {
+		return h.steps[0], h.steps[0]
+	}
+	if i > 0 {
+		lo = (h.steps[i-1] + h.steps[i]) / 2
+	} else {
+		lo = h.steps[0] - (h.steps[1]-h.steps[0])/2
+	}
+	if i < n-1 {
+		hi = (h.steps[i] + h.steps[i+1]) / 2
+	} else {
+		hi = h.steps[n-1] + (h.steps[n-1]-h.steps[n-2])/2
+	}
+	return
+}
@@ -0,0 +1,95 @@
+package xmath_test
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/goinsane/xmath"
+)
+
+func ExampleNewHistogram() {
+	s, err := xmath.NewStepper(3, 10, 0.001, 1e4, 0)
+	if err != nil {
+		panic(err)
+	}
+	_, err = xmath.NewHistogram(s)
+	fmt.Println(err)
+	s, err = xmath.NewStepper(3, 10, 0.001, math.Inf(+1), 0)
+	if err != nil {
+		panic(err)
+	}
+	_, err = xmath.NewHistogram(s)
+	fmt.Println(err)
+
+	// Output:
+	// count 1.0000001e+07: range overflow
+	// range overflow
+}
+
+func ExampleHistogram() {
+	s, err := xmath.NewStepper(0, 10, 10, 50, 10)
+	if err != nil {
+		panic(err)
+	}
+	h, err := xmath.NewHistogram(s)
+	if err != nil {
+		panic(err)
+	}
+	for _, f := range []float64{12, 18, 21, 24, 33, 47, 2, 70} {
+		h.Add(f)
+	}
+	h.AddWeighted(29, 2)
+	fmt.Println(h.Bins(), h.Underflow(), h.Overflow(), h.Total())
+	fmt.Println(h.Cumulative())
+
+	// Output:
+	// [1 3 3 0 1] 1 1 10
+	// [2 5 8 8 9]
+}
+
+func ExampleHistogram_Quantile() {
+	s, err := xmath.NewStepper(0, 10, 10, 40, 10)
+	if err != nil {
+		panic(err)
+	}
+	h, err := xmath.NewHistogram(s)
+	if err != nil {
+		panic(err)
+	}
+	h.AddWeighted(10, 2)
+	h.AddWeighted(20, 4)
+	h.AddWeighted(30, 2)
+	h.AddWeighted(40, 1)
+	h.AddWeighted(100, 1)
+	for _, q := range []float64{0, 0.1, 0.2, 0.5, 0.8, 0.9, 0.95, 1, 1.5} {
+		fmt.Println(q, h.Quantile(q))
+	}
+
+	// Output:
+	// 0 5
+	// 0.1 10
+	// 0.2 15
+	// 0.5 22.5
+	// 0.8 35
+	// 0.9 45
+	// 0.95 +Inf
+	// 1 +Inf
+	// 1.5 NaN
+}
+
+func ExampleHistogram_Merge() {
+	s1, _ := xmath.NewStepper(0, 10, 10, 40, 10)
+	s2, _ := xmath.NewStepper(0, 10, 10, 50, 10)
+	h1, _ := xmath.NewHistogram(s1)
+	h2, _ := xmath.NewHistogram(s1)
+	h3, _ := xmath.NewHistogram(s2)
+	h1.Add(10)
+	h2.Add(20)
+	h2.Add(0)
+	fmt.Println(h1.Merge(h2), h1.Bins(), h1.Underflow())
+	fmt.Println(h1.Merge(h3))
+
+	// Output:
+	// <nil> [1 1 0 0] 1
+	// incompatible steppers
+}
@@ -0,0 +1,132 @@
+package xmath
+
+const (
+	intStepperRound = iota
+	intStepperFloor
+	intStepperCeil
+)
+
+// IntStepper is a utility to step and normalize integer values by given step.
+// Unlike Stepper, it works on int64 values directly and its range is always finite.
+type IntStepper struct {
+	step  int64
+	max   int64
+	min   int64
+	count int64
+}
+
+// NewIntStepper returns a new IntStepper with given step, max, min.
+// The interval between max and min must be a multiple of step.
+func NewIntStepper(step, max, min int64) (s *IntStepper, err error) {
+	if step <= 0 {
+		return nil, ErrStepperStepOverflow
+	}
+	if max < min {
+		return nil, ErrStepperUnorderedMaxMin
+	}
+	intrvl := uint64(max) - uint64(min)
+	if intrvl%uint64(step) != 0 {
+		return nil, ErrStepperRangeOverflow
+	}
+	count := intrvl / uint64(step)
+	if count >= MaxInt64Value {
+		return nil, ErrStepperRangeOverflow
+	}
+	count++
+	return &IntStepper{
+		step:  step,
+		max:   max,
+		min:   min,
+		count: int64(count),
+	}, nil
+}
+
+// Count is same with Count64 if count is less than or equal to MaxIntValue.
+// If count is greater than MaxIntValue, it returns MaxIntValue.
+func (s *IntStepper) Count() int {
+	if s.count > MaxIntValue {
+		return MaxIntValue
+	}
+	return int(s.count)
+}
+
+// Count64 returns number of step for given range.
+func (s *IntStepper) Count64() int64 {
+	return s.count
+}
+
+// Step is same with Step64 except that Step indexes up to MaxIntValue.
+func (s *IntStepper) Step(index int) (int64, error) {
+	return s.Step64(int64(index))
+}
+
+// Step64 returns proper step value by given index.
+func (s *IntStepper) Step64(index int64) (int64, error) {
+	if index >= s.count {
+		return s.max, ErrStepperMaxExceeded
+	}
+	if index < 0 {
+		return s.min, ErrStepperMinExceeded
+	}
+	return int64(uint64(s.min) + uint64(index)*uint64(s.step)), nil
+}
+
+// Index returns index of the nearest step to x, rounding half up.
+// If the index is out of range, it returns the index of max or min with an error.
+func (s *IntStepper) Index(x int64) (int64, error) {
+	return s.index(x, intStepperRound)
+}
+
+// Normalize returns the nearest step to x, rounding half up.
+func (s *IntStepper) Normalize(x int64) (int64, error) {
+	return s.normalize(x, intStepperRound)
+}
+
+// NormalizeFloor returns the greatest step less than or equal to x.
+func (s *IntStepper) NormalizeFloor(x int64) (int64, error) {
+	return s.normalize(x, intStepperFloor)
+}
+
+// NormalizeCeil returns the least step greater than or equal to x.
+func (s *IntStepper) NormalizeCeil(x int64) (int64, error) {
+	return s.normalize(x, intStepperCeil)
+}
+
+func (s *IntStepper) normalize(x int64, mode int) (int64, error) {
+	index, err := s.index(x, mode)
+	switch err {
+	case ErrStepperMaxExceeded:
+		return s.max, err
+	case ErrStepperMinExceeded:
+		return s.min, err
+	}
+	return s.Step64(index)
+}
+
+func (s *IntStepper) index(x int64, mode int) (int64, error) {
+	step := uint64(s.step)
+	if x < s.min {
+		d := uint64(s.min) - uint64(x)
+		q, r := d/step, d%step
+		if q == 0 && (mode == intStepperCeil || (mode == intStepperRound && 2*r <= step)) {
+			return 0, nil
+		}
+		return 0, ErrStepperMinExceeded
+	}
+	d := uint64(x) - uint64(s.min)
+	q, r := d/step, d%step
+	switch mode {
+	case intStepperRound:
+		if 2*r >= step {
+			q++
+		}
+	case intStepperCeil:
+		if r > 0 {
+			q++
+		}
+	}
+	if q >= uint64(s.count) {
+		return s.count - 1, ErrStepperMaxExceeded
+	}
+	return int64(q), nil
+}
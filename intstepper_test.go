@@ -0,0 +1,112 @@
+package xmath_test
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/goinsane/xmath"
+)
+
+func ExampleNewIntStepper() {
+	var err error
+	_, err = xmath.NewIntStepper(5, 100, 10)
+	fmt.Println(err)
+	_, err = xmath.NewIntStepper(0, 100, 10)
+	fmt.Println(err)
+	_, err = xmath.NewIntStepper(7, 100, 10)
+	fmt.Println(err)
+	_, err = xmath.NewIntStepper(5, 10, 100)
+	fmt.Println(err)
+	_, err = xmath.NewIntStepper(1, math.MaxInt64, math.MinInt64)
+	fmt.Println(err)
+	_, err = xmath.NewIntStepper(2, math.MaxInt64-1, math.MinInt64+2)
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// step overflow
+	// range overflow
+	// unordered max min
+	// range overflow
+	// <nil>
+}
+
+func ExampleIntStepper_Step() {
+	s, err := xmath.NewIntStepper(250, 1000, -250)
+	if err != nil {
+		panic(err)
+	}
+	for i := -1; i <= s.Count(); i++ {
+		fmt.Println(s.Step(i))
+	}
+
+	// Output:
+	// -250 min exceeded
+	// -250 <nil>
+	// 0 <nil>
+	// 250 <nil>
+	// 500 <nil>
+	// 750 <nil>
+	// 1000 <nil>
+	// 1000 max exceeded
+}
+
+func ExampleIntStepper_Step_wide() {
+	s, err := xmath.NewIntStepper(math.MaxInt64, math.MaxInt64, math.MinInt64+1)
+	if err != nil {
+		panic(err)
+	}
+	for i := 0; i < s.Count(); i++ {
+		fmt.Println(s.Step(i))
+	}
+
+	// Output:
+	// -9223372036854775807 <nil>
+	// 0 <nil>
+	// 9223372036854775807 <nil>
+}
+
+func ExampleIntStepper_Normalize() {
+	s, err := xmath.NewIntStepper(10, 100, 0)
+	if err != nil {
+		panic(err)
+	}
+	for _, x := range []int64{-6, -5, 0, 14, 15, 16, 100, 104, 105, math.MinInt64, math.MaxInt64} {
+		a, errA := s.Normalize(x)
+		b, errB := s.NormalizeFloor(x)
+		c, errC := s.NormalizeCeil(x)
+		fmt.Printf("%d: round=%d(%v) floor=%d(%v) ceil=%d(%v)\n", x, a, errA, b, errB, c, errC)
+	}
+
+	// Output:
+	// -6: round=0(min exceeded) floor=0(min exceeded) ceil=0(<nil>)
+	// -5: round=0(<nil>) floor=0(min exceeded) ceil=0(<nil>)
+	// 0: round=0(<nil>) floor=0(<nil>) ceil=0(<nil>)
+	// 14: round=10(<nil>) floor=10(<nil>) ceil=20(<nil>)
+	// 15: round=20(<nil>) floor=10(<nil>) ceil=20(<nil>)
+	// 16: round=20(<nil>) floor=10(<nil>) ceil=20(<nil>)
+	// 100: round=100(<nil>) floor=100(<nil>) ceil=100(<nil>)
+	// 104: round=100(<nil>) floor=100(<nil>) ceil=100(max exceeded)
+	// 105: round=100(max exceeded) floor=100(<nil>) ceil=100(max exceeded)
+	// -9223372036854775808: round=0(min exceeded) floor=0(min exceeded) ceil=0(min exceeded)
+	// 9223372036854775807: round=100(max exceeded) floor=100(max exceeded) ceil=100(max exceeded)
+}
+
+func ExampleIntStepper_Index() {
+	s, err := xmath.NewIntStepper(3, 30, 0)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s.Index(-2))
+	fmt.Println(s.Index(4))
+	fmt.Println(s.Index(5))
+	fmt.Println(s.Index(31))
+	fmt.Println(s.Index(32))
+
+	// Output:
+	// 0 min exceeded
+	// 1 <nil>
+	// 2 <nil>
+	// 10 <nil>
+	// 10 max exceeded
+}
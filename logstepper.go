@@ -0,0 +1,262 @@
+package xmath
+
+import (
+	"math"
+	"math/big"
+	"sort"
+)
+
+// MaxGeometricStepperCount is the max number of steps of GeometricStepper.
+const MaxGeometricStepperCount = 1 << 16
+
+// listStepper is the common part of steppers which keep their steps in an ascending list.
+// The values below and above are the neighbour steps out of range, and used to decide whether the range is exceeded.
+type listStepper struct {
+	prec  int
+	base  int
+	steps []float64
+	below float64
+	above float64
+}
+
+func (s *listStepper) newReal() *Real {
+	return NewReal(s.prec, s.base)
+}
+
+// Prec returns precision of the stepper.
+func (s *listStepper) Prec() int {
+	return s.prec
+}
+
+// Base returns base of the stepper.
+func (s *listStepper) Base() int {
+	return s.base
+}
+
+// Count is same with Count64 if count is less than or equal to MaxIntValue.
+// If count is greater than MaxIntValue, it returns MaxIntValue.
+func (s *listStepper) Count() int {
+	if int64(len(s.steps)) > MaxIntValue {
+		return MaxIntValue
+	}
+	return len(s.steps)
+}
+
+// Count64 returns number of step for given range.
+func (s *listStepper) Count64() int64 {
+	return int64(len(s.steps))
+}
+
+// Step is same with Step64 except that Step indexes up to MaxIntValue.
+func (s *listStepper) Step(index int) (float64, error) {
+	return s.Step64(int64(index))
+}
+
+// Step64 returns proper step value by given index.
+func (s *listStepper) Step64(index int64) (float64, error) {
+	if index >= int64(len(s.steps)) {
+		return s.steps[len(s.steps)-1], ErrStepperMaxExceeded
+	}
+	if index < 0 {
+		return s.steps[0], ErrStepperMinExceeded
+	}
+	return s.steps[index], nil
+}
+
+// Normalize returns the nearest step to f, rounding half up.
+// f is rounded by precision and base of the stepper before normalizing.
+func (s *listStepper) Normalize(f float64) (float64, error) {
+	if math.IsNaN(f) {
+		return f, nil
+	}
+	if math.IsInf(f, +1) {
+		return f, ErrStepperMaxExceeded
+	}
+	if math.IsInf(f, -1) {
+		return f, ErrStepperMinExceeded
+	}
+	index, err := s.Index(f)
+	return s.steps[index], err
+}
+
+// Index returns index of the step which f is normalized to.
+// If the normalized step is out of range, it returns the index of the nearest bound with an error.
+func (s *listStepper) Index(f float64) (int64, error) {
+	if math.IsNaN(f) {
+		return 0, ErrStepperNaN
+	}
+	n := len(s.steps)
+	if math.IsInf(f, +1) {
+		return int64(n - 1), ErrStepperMaxExceeded
+	}
+	if math.IsInf(f, -1) {
+		return 0, ErrStepperMinExceeded
+	}
+	x, _ := s.newReal().SetFloat64(f).Float64()
+	i := sort.SearchFloat64s(s.steps, x)
+	switch {
+	case i >= n:
+		if s.roundsUp(x, s.steps[n-1], s.above) {
+			return int64(n - 1), ErrStepperMaxExceeded
+		}
+		return int64(n - 1), nil
+	case s.steps[i] == x:
+		return int64(i), nil
+	case i == 0:
+		if !s.roundsUp(x, s.below, s.steps[0]) {
+			return 0, ErrStepperMinExceeded
+		}
+		return 0, nil
+	}
+	if s.roundsUp(x, s.steps[i-1], s.steps[i]) {
+		return int64(i), nil
+	}
+	return int64(i - 1), nil
+}
+
+// Contains checks f is exactly one of the steps of the stepper.
+func (s *listStepper) Contains(f float64) bool {
+	return containsStep(s, f)
+}
+
+// roundsUp reports whether x is nearer to b than a, or in the middle of them.
+// The distances are rounded by precision and base of the stepper before comparing.
+func (s *listStepper) roundsUp(x float64, a, b float64) bool {
+	x1 := new(big.Rat).SetFloat64(x)
+	d1 := s.newReal().SetRat(new(big.Rat).Sub(x1, new(big.Rat).SetFloat64(a)))
+	d2 := s.newReal().SetRat(new(big.Rat).Sub(new(big.Rat).SetFloat64(b), x1))
+	return d1.Cmp(d2) >= 0
+}
+
+// GeometricStepper is a utility to step and normalize floating point values on a geometric grid by given precision and base.
+// Each step is the previous step multiplied by the ratio, and rounded by given precision and base.
+type GeometricStepper struct {
+	listStepper
+}
+
+// NewGeometricStepper returns a new GeometricStepper with given precision, base and given ratio, max, min.
+// The first step is min, and the last step is the greatest step less than or equal to max.
+// Both of max and min must be positive and finite, and ratio must be greater than 1.
+// It returns StepperError with ErrStepperMaxOverflow if the number of steps is greater than MaxGeometricStepperCount.
+// It panics unless base is in valid range.
+func NewGeometricStepper(prec, base int, ratio, max, min float64) (s *GeometricStepper, err error) {
+	panicForInvalidBase(base)
+	s = &GeometricStepper{
+		listStepper: listStepper{
+			prec: prec,
+			base: base,
+		},
+	}
+	if !(ratio > 1) || math.IsInf(ratio, 0) {
+		return nil, ErrStepperStepOverflow
+	}
+	if !(max > 0) || math.IsInf(max, 0) {
+		return nil, ErrStepperMaxOverflow
+	}
+	minReal := s.newReal()
+	if !(min > 0) || math.IsInf(min, 0) {
+		return nil, ErrStepperMinOverflow
+	}
+	if f, acc := minReal.SetFloat64(min).Float64(); f != min || acc != big.Exact {
+		return nil, ErrStepperMinOverflow
+	}
+	if max < min {
+		return nil, ErrStepperUnorderedMaxMin
+	}
+	if n := (math.Log(max) - math.Log(min)) / math.Log1p(ratio-1); !(n < MaxGeometricStepperCount) {
+		return nil, newStepperError("max", max, ErrStepperMaxOverflow)
+	}
+	s.below = min / ratio
+	ratioFloat := big.NewFloat(ratio)
+	stepReal := minReal
+	for {
+		step, _ := stepReal.Float64()
+		if step > max {
+			s.above = step
+			break
+		}
+		if len(s.steps) >= MaxGeometricStepperCount {
+			return nil, newStepperError("max", max, ErrStepperMaxOverflow)
+		}
+		s.steps = append(s.steps, step)
+		next := s.newReal().SetFloat(new(big.Float).SetPrec(2*53).Mul(stepReal.Float(), ratioFloat))
+		if next.Cmp(stepReal) <= 0 || next.IsInf() {
+			return nil, ErrStepperStepOverflow
+		}
+		stepReal = next
+	}
+	return s, nil
+}
+
+// DecadeStepper is a utility to step and normalize floating point values on a logarithmic decade grid by given precision and base.
+// The steps are the mantissas multiplied by the powers of 10, such as 1, 2, 5, 10, 20, 50, 100 for the mantissas 1, 2, 5.
+type DecadeStepper struct {
+	listStepper
+}
+
+// NewDecadeStepper returns a new DecadeStepper with given precision, base and given mantissas, max, min.
+// The mantissas must be in [1, 10) and in ascending order.
+// Both of max and min must be positive, finite and on the grid.
+// It panics unless base is in valid range.
+func NewDecadeStepper(prec, base int, mantissas []float64, max, min float64) (s *DecadeStepper, err error) {
+	panicForInvalidBase(base)
+	s = &DecadeStepper{
+		listStepper: listStepper{
+			prec: prec,
+			base: base,
+		},
+	}
+	if len(mantissas) <= 0 {
+		return nil, ErrStepperStepOverflow
+	}
+	for i, m := range mantissas {
+		if !(1 <= m && m < 10) || (i > 0 && m <= mantissas[i-1]) {
+			return nil, ErrStepperStepOverflow
+		}
+	}
+	if !(max > 0) || math.IsInf(max, 0) {
+		return nil, ErrStepperMaxOverflow
+	}
+	if !(min > 0) || math.IsInf(min, 0) {
+		return nil, ErrStepperMinOverflow
+	}
+	if max < min {
+		return nil, ErrStepperUnorderedMaxMin
+	}
+	var last *Real
+	for exp := int(math.Floor(math.Log10(min))) - 1; ; exp++ {
+		for _, m := range mantissas {
+			v := decadeValue(m, exp)
+			if x, _ := v.Float64(); x < min {
+				s.below = x
+				continue
+			}
+			stepReal := s.newReal().SetFloat(v)
+			step, _ := stepReal.Float64()
+			if len(s.steps) <= 0 && step != min {
+				return nil, ErrStepperMinOverflow
+			}
+			if last != nil && stepReal.Cmp(last) <= 0 {
+				return nil, ErrStepperStepOverflow
+			}
+			if step > max {
+				if s.steps[len(s.steps)-1] != max {
+					return nil, ErrStepperMaxOverflow
+				}
+				s.above = step
+				return s, nil
+			}
+			s.steps = append(s.steps, step)
+			last = stepReal
+		}
+	}
+}
+
+// decadeValue returns m*10^exp as big.Float.
+func decadeValue(m float64, exp int) *big.Float {
+	x := new(big.Float).SetPrec(256).SetFloat64(m)
+	if exp < 0 {
+		return x.Quo(x, new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-exp)), nil)))
+	}
+	return x.Mul(x, new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)))
+}
@@ -0,0 +1,154 @@
+package xmath_test
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/goinsane/xmath"
+)
+
+func ExampleNewGeometricStepper() {
+	var err error
+	_, err = xmath.NewGeometricStepper(2, 10, 1.5, 100, 1)
+	fmt.Println(err)
+	_, err = xmath.NewGeometricStepper(2, 10, 1, 100, 1)
+	fmt.Println(err)
+	_, err = xmath.NewGeometricStepper(2, 10, 1.5, math.Inf(+1), 1)
+	fmt.Println(err)
+	_, err = xmath.NewGeometricStepper(2, 10, 1.5, 100, 0)
+	fmt.Println(err)
+	_, err = xmath.NewGeometricStepper(2, 10, 1.5, 100, 1.005)
+	fmt.Println(err)
+	_, err = xmath.NewGeometricStepper(2, 10, 1.5, 1, 100)
+	fmt.Println(err)
+	_, err = xmath.NewGeometricStepper(0, 10, 1.2, 100, 1)
+	fmt.Println(err)
+	_, err = xmath.NewGeometricStepper(6, 10, 1.00001, 1e6, 1)
+	fmt.Println(err)
+	_, err = xmath.NewGeometricStepper(6, 10, 1.001, 1e6, 1)
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// step overflow
+	// max overflow
+	// min overflow
+	// min overflow
+	// unordered max min
+	// step overflow
+	// max 1e+06: max overflow
+	// <nil>
+}
+
+func ExampleGeometricStepper_Step() {
+	s, err := xmath.NewGeometricStepper(1, 10, 2.5, 500, 0.4)
+	if err != nil {
+		panic(err)
+	}
+	for i := 0; i <= s.Count(); i++ {
+		fmt.Println(s.Step(i))
+	}
+
+	// Output:
+	// 0.4 <nil>
+	// 1 <nil>
+	// 2.5 <nil>
+	// 6.3 <nil>
+	// 15.8 <nil>
+	// 39.5 <nil>
+	// 98.8 <nil>
+	// 247 <nil>
+	// 247 max exceeded
+}
+
+func ExampleGeometricStepper_Normalize() {
+	s, err := xmath.NewGeometricStepper(0, 10, 2, 1000, 1)
+	if err != nil {
+		panic(err)
+	}
+	for _, f := range []float64{0.4, 0.8, 1.4, 1.5, 6.2, 100, 767, 768, 1e6} {
+		fmt.Println(s.Normalize(f))
+	}
+
+	// Output:
+	// 1 min exceeded
+	// 1 <nil>
+	// 1 <nil>
+	// 2 <nil>
+	// 8 <nil>
+	// 128 <nil>
+	// 512 <nil>
+	// 512 max exceeded
+	// 512 max exceeded
+}
+
+func ExampleNewDecadeStepper() {
+	var err error
+	_, err = xmath.NewDecadeStepper(2, 10, []float64{1, 2, 5}, 500, 0.01)
+	fmt.Println(err)
+	_, err = xmath.NewDecadeStepper(2, 10, []float64{1, 5, 2}, 500, 0.01)
+	fmt.Println(err)
+	_, err = xmath.NewDecadeStepper(2, 10, []float64{1, 2, 5}, 400, 0.01)
+	fmt.Println(err)
+	_, err = xmath.NewDecadeStepper(2, 10, []float64{1, 2, 5}, 500, 0.03)
+	fmt.Println(err)
+	_, err = xmath.NewDecadeStepper(2, 10, []float64{1, 2, 5}, 500, 0.001)
+	fmt.Println(err)
+	_, err = xmath.NewDecadeStepper(1, 10, []float64{1, 1.2, 1.5}, 10, 0.1)
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// step overflow
+	// max overflow
+	// min overflow
+	// min overflow
+	// step overflow
+}
+
+func ExampleDecadeStepper_Step() {
+	s, err := xmath.NewDecadeStepper(2, 10, []float64{1, 2, 5}, 500, 0.05)
+	if err != nil {
+		panic(err)
+	}
+	for i := 0; i < s.Count(); i++ {
+		fmt.Println(s.Step(i))
+	}
+
+	// Output:
+	// 0.05 <nil>
+	// 0.1 <nil>
+	// 0.2 <nil>
+	// 0.5 <nil>
+	// 1 <nil>
+	// 2 <nil>
+	// 5 <nil>
+	// 10 <nil>
+	// 20 <nil>
+	// 50 <nil>
+	// 100 <nil>
+	// 200 <nil>
+	// 500 <nil>
+}
+
+func ExampleDecadeStepper_Index() {
+	var s xmath.Scale
+	s, err := xmath.NewDecadeStepper(2, 10, []float64{1, 2, 5}, 500, 0.1)
+	if err != nil {
+		panic(err)
+	}
+	for _, f := range []float64{0.07, 0.08, 0.15, 3.5, 3.49, 7.5, 740, 760, math.NaN()} {
+		fmt.Println(s.Index(f))
+	}
+
+	// Output:
+	// 0 min exceeded
+	// 0 <nil>
+	// 1 <nil>
+	// 5 <nil>
+	// 4 <nil>
+	// 6 <nil>
+	// 11 <nil>
+	// 11 max exceeded
+	// 0 NaN value
+}
@@ -16,6 +16,7 @@ type Real struct {
 	base int
 	f    *big.Float
 	k    *big.Float
+	i    *big.Int
 }
 
 // NewReal returns a new Real with given precision and base.
@@ -125,13 +126,25 @@ func (z *Real) SetFloatPrec(prec uint) *Real {
 	return z
 }
 
+var (
+	realHalf = big.NewFloat(0.5)
+	realOne  = big.NewInt(1)
+)
+
 func (z *Real) round() {
 	if z.f.IsInf() {
 		return
 	}
+	if z.i == nil {
+		z.i = new(big.Int)
+	}
 	z.f.Mul(z.f, z.k)
-	z.f.Add(z.f, big.NewFloat(0.5))
-	z.f.SetInt(FloorBigFloat(z.f))
+	z.f.Add(z.f, realHalf)
+	n, acc := z.f.Int(z.i)
+	if acc == big.Above {
+		n.Sub(n, realOne)
+	}
+	z.f.SetInt(n)
 	z.f.Quo(z.f, z.k)
 }
 
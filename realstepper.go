@@ -0,0 +1,147 @@
+package xmath
+
+import (
+	"math/big"
+)
+
+// RealStepper is a companion of Stepper to step and normalize Real values by given precision and base.
+// The steps are computed exactly in units of precision, and they must be accurate to precision in Real.
+type RealStepper struct {
+	s *Stepper
+	k *big.Rat
+}
+
+// NewRealStepper returns a new RealStepper with given step, max, min like NewStepper.
+// The precision and base of RealStepper are the precision and base of step.
+// All of step, max and min must have same precision and base, and must be exactly representable as float64.
+func NewRealStepper(step, max, min *Real) (*RealStepper, error) {
+	prec, base := step.Prec(), step.Base()
+	values := []float64{0, 0, 0}
+	for i, x := range []*Real{step, max, min} {
+		f, acc := x.Float64()
+		values[i] = f
+		if x.Prec() != prec || x.Base() != base {
+			return nil, newStepperError(stepperFields[i], f, ErrStepperIncompatible)
+		}
+		if acc != big.Exact {
+			return nil, newStepperError(stepperFields[i], f, stepperOverflows[i])
+		}
+	}
+	s, err := NewStepper(prec, base, values[0], values[1], values[2])
+	if err != nil {
+		return nil, err
+	}
+	return &RealStepper{
+		s: s,
+		k: precRat(prec, base),
+	}, nil
+}
+
+var (
+	stepperFields    = []string{"step", "max", "min"}
+	stepperOverflows = []error{ErrStepperStepOverflow, ErrStepperMaxOverflow, ErrStepperMinOverflow}
+)
+
+// Stepper returns the underlying Stepper.
+func (s *RealStepper) Stepper() *Stepper {
+	return s.s
+}
+
+// Prec returns precision of the RealStepper.
+func (s *RealStepper) Prec() int {
+	return s.s.Prec()
+}
+
+// Base returns base of the RealStepper.
+func (s *RealStepper) Base() int {
+	return s.s.Base()
+}
+
+// Count is same with Count64 if count is less than or equal to MaxIntValue.
+// If count is greater than MaxIntValue, it returns MaxIntValue.
+func (s *RealStepper) Count() int {
+	return s.s.Count()
+}
+
+// Count64 returns number of step for given range.
+// If the range of RealStepper is infinity, it returns 0.
+func (s *RealStepper) Count64() int64 {
+	return s.s.Count64()
+}
+
+// Step is same with Step64 except that Step indexes up to MaxIntValue.
+func (s *RealStepper) Step(index int) (*Real, error) {
+	return s.Step64(int64(index))
+}
+
+// Step64 returns proper step value by given index like Stepper.
+// It returns ErrStepperInexact if the step isn't accurate to precision in Real.
+func (s *RealStepper) Step64(index int64) (*Real, error) {
+	return s.StepBig(big.NewInt(index))
+}
+
+// StepBig is same with Step64 except that StepBig indexes beyond int64.
+func (s *RealStepper) StepBig(index *big.Int) (*Real, error) {
+	if !s.s.maxReal.IsInf() && index.Cmp(s.s.maxIndexBig()) > 0 {
+		return s.s.newReal().Set(s.s.maxReal), ErrStepperMaxExceeded
+	}
+	if !s.s.minReal.IsInf() && index.Sign() < 0 {
+		return s.s.newReal().Set(s.s.minReal), ErrStepperMinExceeded
+	}
+	i := new(big.Int).Mul(index, s.s.stepUnits(s.k))
+	i.Add(i, s.s.originUnits(s.k))
+	q := new(big.Rat).SetInt(i)
+	x := s.s.newReal().SetRat(q.Quo(q, s.k))
+	if x.IsInf() {
+		if x.Sign() > 0 {
+			return x, ErrStepperMaxExceeded
+		}
+		return x, ErrStepperMinExceeded
+	}
+	r, _ := x.Rat(nil)
+	if FloorBigRat(r.Add(r.Mul(r, s.k), big.NewRat(1, 2))).Cmp(i) != 0 {
+		return x, ErrStepperInexact
+	}
+	return x, nil
+}
+
+// Normalize returns normalized value of x by proper index like Stepper.
+// It returns ErrStepperInexact if the result isn't accurate to precision in Real.
+func (s *RealStepper) Normalize(x *Real) (*Real, error) {
+	index, err := s.IndexBig(x)
+	switch err {
+	case nil:
+		return s.StepBig(index)
+	case ErrStepperMaxExceeded:
+		if !s.s.maxReal.IsInf() {
+			return s.s.newReal().Set(s.s.maxReal), err
+		}
+	case ErrStepperMinExceeded:
+		if !s.s.minReal.IsInf() {
+			return s.s.newReal().Set(s.s.minReal), err
+		}
+	}
+	return s.s.newReal().Set(x), err
+}
+
+// Index returns index of the step which x is normalized to like Stepper.
+func (s *RealStepper) Index(x *Real) (int64, error) {
+	f, _ := x.Float64()
+	return s.s.Index(f)
+}
+
+// IndexBig is same with Index except that IndexBig indexes beyond int64 in infinite ranges.
+func (s *RealStepper) IndexBig(x *Real) (*big.Int, error) {
+	f, _ := x.Float64()
+	return s.s.IndexBig(f)
+}
+
+// Contains checks x is exactly one of the steps of RealStepper.
+func (s *RealStepper) Contains(x *Real) bool {
+	index, err := s.IndexBig(x)
+	if err != nil {
+		return false
+	}
+	y, err := s.StepBig(index)
+	return err == nil && y.Cmp(x) == 0
+}
@@ -0,0 +1,65 @@
+package xmath_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/goinsane/xmath"
+)
+
+func ExampleNewRealStepper() {
+	var err error
+	_, err = xmath.NewRealStepper(xmath.NewDecimal(2).SetFloat64(0.05), xmath.NewDecimal(2).SetFloat64(100), xmath.NewDecimal(2).SetFloat64(-100))
+	fmt.Println(err)
+	_, err = xmath.NewRealStepper(xmath.NewDecimal(2).SetFloat64(0.05), xmath.NewDecimal(3).SetFloat64(100), xmath.NewDecimal(2).SetFloat64(-100))
+	fmt.Println(err)
+	_, err = xmath.NewRealStepper(xmath.NewDecimal(2).SetFloat64(0.05), xmath.NewDecimal(2).SetFloat64(100), xmath.NewDecimal(2).SetInf(true))
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// max 100: incompatible steppers
+	// <nil>
+}
+
+func ExampleRealStepper_Step() {
+	s, err := xmath.NewRealStepper(xmath.NewDecimal(2).SetFloat64(0.01), xmath.NewDecimal(2).SetInf(false), xmath.NewDecimal(2).SetFloat64(0))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s.Step(0))
+	fmt.Println(s.Step(123))
+	fmt.Println(s.Step(-1))
+	fmt.Println(s.StepBig(new(big.Int).Exp(big.NewInt(10), big.NewInt(15), nil)))
+	fmt.Println(s.StepBig(new(big.Int).Exp(big.NewInt(10), big.NewInt(17), nil)))
+	fmt.Println(s.StepBig(new(big.Int).Add(new(big.Int).Exp(big.NewInt(10), big.NewInt(17), nil), big.NewInt(1))))
+
+	// Output:
+	// 0 <nil>
+	// 1.23 <nil>
+	// 0 min exceeded
+	// 1e+13 <nil>
+	// 1e+15 <nil>
+	// 1e+15 inexact result
+}
+
+func ExampleRealStepper_Normalize() {
+	s, err := xmath.NewRealStepper(xmath.NewDecimal(2).SetFloat64(0.05), xmath.NewDecimal(2).SetFloat64(100), xmath.NewDecimal(2).SetFloat64(-100))
+	if err != nil {
+		panic(err)
+	}
+	for _, f := range []float64{0.1, 0.12, 0.13, 33.33, -99.99, 100.1, -101} {
+		x := xmath.NewDecimal(2).SetFloat64(f)
+		y, err := s.Normalize(x)
+		fmt.Println(y, err, s.Contains(x))
+	}
+
+	// Output:
+	// 0.1 <nil> true
+	// 0.1 <nil> false
+	// 0.15 <nil> false
+	// 33.35 <nil> false
+	// -100 <nil> false
+	// 100 max exceeded false
+	// -100 min exceeded false
+}
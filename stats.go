@@ -0,0 +1,248 @@
+package xmath
+
+import (
+	"math"
+	"sort"
+)
+
+// Variance returns the population variance of x... by Welford's algorithm.
+//
+// Special cases are:
+//	Variance(x, NaN) = NaN
+//	Variance() = NaN
+func Variance(x ...float64) float64 {
+	n, _, m2 := welford(x)
+	if n < 1 {
+		return math.NaN()
+	}
+	return m2 / float64(n)
+}
+
+// SampleVariance returns the sample variance of x... with Bessel's correction by Welford's algorithm.
+//
+// Special cases are:
+//	SampleVariance(x, NaN) = NaN
+//	SampleVariance(x) = NaN
+//	SampleVariance() = NaN
+func SampleVariance(x ...float64) float64 {
+	n, _, m2 := welford(x)
+	if n < 2 {
+		return math.NaN()
+	}
+	return m2 / float64(n-1)
+}
+
+// StdDev returns the population standard deviation of x...
+// The special cases are same with Variance.
+func StdDev(x ...float64) float64 {
+	return math.Sqrt(Variance(x...))
+}
+
+// SampleStdDev returns the sample standard deviation of x...
+// The special cases are same with SampleVariance.
+func SampleStdDev(x ...float64) float64 {
+	return math.Sqrt(SampleVariance(x...))
+}
+
+// welford returns the count, mean and sum of squares of differences from the mean of x.
+// The mean and the sum of squares are NaN if any of x is NaN.
+func welford(x []float64) (n int, mean float64, m2 float64) {
+	for _, a := range x {
+		n++
+		d := a - mean
+		mean += d / float64(n)
+		m2 += d * (a - mean)
+	}
+	return
+}
+
+// QuantileMethod determines the interpolation of Quantile by the sample quantile definitions of Hyndman and Fan.
+type QuantileMethod int
+
+const (
+	// QuantileR1 is the inverse of empirical distribution function.
+	QuantileR1 QuantileMethod = iota + 1
+	// QuantileR2 is similar with QuantileR1 with averaging at discontinuities.
+	QuantileR2
+	// QuantileR3 is the observation numbered closest to n*q, rounding half to even.
+	QuantileR3
+	// QuantileR4 is the linear interpolation of the empirical distribution function.
+	QuantileR4
+	// QuantileR5 is the piecewise linear function where the knots are the midpoints of the steps of the empirical distribution function.
+	QuantileR5
+	// QuantileR6 is the linear interpolation of the expectations for the order statistics of the uniform distribution.
+	QuantileR6
+	// QuantileR7 is the linear interpolation of the modes for the order statistics of the uniform distribution.
+	QuantileR7
+	// QuantileR8 is the linear interpolation of the approximate medians for order statistics.
+	QuantileR8
+	// QuantileR9 is approximately unbiased for the expected order statistics if x is normally distributed.
+	QuantileR9
+
+	// QuantileNearestRank is synonym with QuantileR1.
+	QuantileNearestRank = QuantileR1
+	// QuantileLinear is synonym with QuantileR7 which is the default of R, NumPy and spreadsheets.
+	QuantileLinear = QuantileR7
+)
+
+// Quantile returns the q-quantile of x... by given method.
+// It panics unless method is in valid range.
+//
+// Special cases are:
+//	Quantile(q, method, x, NaN) = NaN
+//	Quantile(q, method) = NaN
+//	Quantile(q, method, x...) = NaN if q is out of [0, 1]
+func Quantile(q float64, method QuantileMethod, x ...float64) float64 {
+	if method < QuantileR1 || method > QuantileR9 {
+		panic("invalid quantile method")
+	}
+	if !(0 <= q && q <= 1) || len(x) <= 0 {
+		return math.NaN()
+	}
+	sorted, ok := sortedCopy(x)
+	if !ok {
+		return math.NaN()
+	}
+	return quantileSorted(q, method, sorted)
+}
+
+// Median returns the median of x... which is same with Quantile(0.5, QuantileR7, x...).
+func Median(x ...float64) float64 {
+	return Quantile(0.5, QuantileR7, x...)
+}
+
+// MAD returns the median absolute deviation of x... without scaling.
+// The special cases are same with Median.
+func MAD(x ...float64) float64 {
+	m := Median(x...)
+	if math.IsNaN(m) {
+		return m
+	}
+	d := make([]float64, len(x))
+	for i, a := range x {
+		d[i] = math.Abs(a - m)
+	}
+	return Median(d...)
+}
+
+// Skewness returns the population skewness of x... which is the third standardized moment.
+//
+// Special cases are:
+//	Skewness(x, NaN) = NaN
+//	Skewness() = NaN
+//	Skewness(x...) = NaN if the variance of x is 0
+func Skewness(x ...float64) float64 {
+	m2, m3, _ := centralMoments(x)
+	return m3 / math.Pow(m2, 1.5)
+}
+
+// Kurtosis returns the population excess kurtosis of x... which is the fourth standardized moment minus 3.
+//
+// Special cases are:
+//	Kurtosis(x, NaN) = NaN
+//	Kurtosis() = NaN
+//	Kurtosis(x...) = NaN if the variance of x is 0
+func Kurtosis(x ...float64) float64 {
+	m2, _, m4 := centralMoments(x)
+	return m4/(m2*m2) - 3
+}
+
+// Mode returns the most frequent value of x...
+// If there are more than one most frequent values, it returns the smallest one.
+//
+// Special cases are:
+//	Mode(x, NaN) = NaN
+//	Mode() = NaN
+func Mode(x ...float64) float64 {
+	sorted, ok := sortedCopy(x)
+	if !ok || len(sorted) <= 0 {
+		return math.NaN()
+	}
+	result, best := sorted[0], 0
+	for i := 0; i < len(sorted); {
+		j := i + 1
+		for j < len(sorted) && sorted[j] == sorted[i] {
+			j++
+		}
+		if j-i > best {
+			result, best = sorted[i], j-i
+		}
+		i = j
+	}
+	return result
+}
+
+// centralMoments returns the second, third and fourth central moments of x.
+// They are NaN if x is empty or any of x is NaN.
+func centralMoments(x []float64) (m2, m3, m4 float64) {
+	if len(x) <= 0 {
+		return math.NaN(), math.NaN(), math.NaN()
+	}
+	mean := Avg(x...)
+	for _, a := range x {
+		d := a - mean
+		d2 := d * d
+		m2 += d2
+		m3 += d2 * d
+		m4 += d2 * d2
+	}
+	n := float64(len(x))
+	return m2 / n, m3 / n, m4 / n
+}
+
+// sortedCopy returns the sorted copy of x. It returns false if any of x is NaN.
+func sortedCopy(x []float64) ([]float64, bool) {
+	sorted := make([]float64, len(x))
+	for i, a := range x {
+		if math.IsNaN(a) {
+			return nil, false
+		}
+		sorted[i] = a
+	}
+	sort.Float64s(sorted)
+	return sorted, true
+}
+
+// quantileSorted returns the q-quantile of sorted x by given method.
+func quantileSorted(q float64, method QuantileMethod, x []float64) float64 {
+	n := float64(len(x))
+	// at returns the observation by 1-based index k which is clamped to the range.
+	at := func(k float64) float64 {
+		i := int(math.Max(1, math.Min(n, k)))
+		return x[i-1]
+	}
+	switch method {
+	case QuantileR1:
+		return at(math.Ceil(n * q))
+	case QuantileR2:
+		h := n*q + 0.5
+		return (at(math.Ceil(h-0.5)) + at(math.Floor(h+0.5))) / 2
+	case QuantileR3:
+		return at(math.RoundToEven(n * q))
+	}
+	var h float64
+	switch method {
+	case QuantileR4:
+		h = n * q
+	case QuantileR5:
+		h = n*q + 0.5
+	case QuantileR6:
+		h = (n + 1) * q
+	case QuantileR7:
+		h = (n-1)*q + 1
+	case QuantileR8:
+		h = (n+1.0/3)*q + 1.0/3
+	case QuantileR9:
+		h = (n+0.25)*q + 0.375
+	}
+	lo := math.Floor(h)
+	a, b := at(lo), at(lo+1)
+	if h == lo || a == b {
+		return a
+	}
+	g := h - lo
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return (1-g)*a + g*b
+	}
+	return a + g*(b-a)
+}
@@ -0,0 +1,81 @@
+package xmath_test
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/goinsane/xmath"
+)
+
+func ExampleVariance() {
+	x := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	fmt.Println(xmath.Variance(x...), xmath.StdDev(x...))
+	fmt.Println(xmath.SampleVariance(x...), xmath.SampleStdDev(x...))
+	y := []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}
+	fmt.Println(xmath.Variance(y...), xmath.SampleVariance(y...))
+	fmt.Println(xmath.Variance(1), xmath.SampleVariance(1), xmath.Variance(), xmath.Variance(1, math.NaN()))
+
+	// Output:
+	// 4 2
+	// 4.571428571428571 2.138089935299395
+	// 22.5 30
+	// 0 NaN NaN NaN
+}
+
+func ExampleQuantile() {
+	x := []float64{15, 20, 35, 40, 50}
+	for _, method := range []xmath.QuantileMethod{
+		xmath.QuantileR1,
+		xmath.QuantileR2,
+		xmath.QuantileR3,
+		xmath.QuantileR4,
+		xmath.QuantileR5,
+		xmath.QuantileR6,
+		xmath.QuantileR7,
+		xmath.QuantileR8,
+		xmath.QuantileR9,
+	} {
+		fmt.Printf("R%d %.6g %.6g %.6g %.6g\n", method, xmath.Quantile(0, method, x...), xmath.Quantile(0.3, method, x...), xmath.Quantile(0.4, method, x...), xmath.Quantile(1, method, x...))
+	}
+	fmt.Println(xmath.Quantile(1.5, xmath.QuantileLinear, x...), xmath.Quantile(0.5, xmath.QuantileLinear), xmath.Quantile(0.5, xmath.QuantileLinear, 1, math.NaN()))
+	fmt.Println(xmath.Quantile(0.5, xmath.QuantileLinear, math.Inf(-1), 1), xmath.Quantile(0.5, xmath.QuantileLinear, math.Inf(-1), math.Inf(+1)))
+
+	// Output:
+	// R1 15 20 20 50
+	// R2 15 20 27.5 50
+	// R3 15 20 20 50
+	// R4 15 17.5 20 50
+	// R5 15 20 27.5 50
+	// R6 15 19 26 50
+	// R7 15 23 29 50
+	// R8 15 19.6667 27 50
+	// R9 15 19.75 27.125 50
+	// NaN NaN NaN
+	// -Inf NaN
+}
+
+func ExampleMedian() {
+	fmt.Println(xmath.Median(3, 1, 2), xmath.Median(4, 1, 3, 2), xmath.Median(5), xmath.Median())
+	fmt.Println(xmath.MAD(1, 1, 2, 2, 4, 6, 9), xmath.MAD(), xmath.MAD(1, math.NaN()))
+
+	// Output:
+	// 2 2.5 5 NaN
+	// 1 NaN NaN
+}
+
+func ExampleSkewness() {
+	x := []float64{2, 8, 0, 4, 1, 9, 9, 0}
+	fmt.Printf("%.6f %.6f\n", xmath.Skewness(x...), xmath.Kurtosis(x...))
+	fmt.Println(xmath.Skewness(1, 2, 3), xmath.Kurtosis(1, 1, 1), xmath.Skewness())
+
+	// Output:
+	// 0.265055 -1.666001
+	// 0 NaN NaN
+}
+
+func ExampleMode() {
+	fmt.Println(xmath.Mode(1, 2, 2, 3, 3, 3), xmath.Mode(3, 1, 2, 1, 2), xmath.Mode(), xmath.Mode(1, math.NaN()))
+
+	// Output:
+	// 3 1 NaN NaN
+}
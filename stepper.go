@@ -1,9 +1,15 @@
 package xmath
 
 import (
+	crand "crypto/rand"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"math"
 	"math/big"
+	"strconv"
+	"strings"
 )
 
 var (
@@ -14,8 +20,53 @@ var (
 	ErrStepperUnorderedMaxMin = errors.New("unordered max min")
 	ErrStepperMaxExceeded     = errors.New("max exceeded")
 	ErrStepperMinExceeded     = errors.New("min exceeded")
+	ErrStepperNaN             = errors.New("NaN value")
+	ErrStepperIncompatible    = errors.New("incompatible steppers")
+	ErrStepperDiscontinuous   = errors.New("discontinuous steppers")
+	ErrStepperAnchorOverflow  = errors.New("anchor overflow")
+	ErrStepperInvalidBase     = errors.New("invalid base")
+	ErrStepperInvalidText     = errors.New("invalid text")
+	ErrStepperInexact         = errors.New("inexact result")
 )
 
+// StepperError is the error of validation of Stepper.
+// It reports which field and value failed validation, and wraps one of ErrStepper errors.
+type StepperError struct {
+	Field string
+	Value float64
+	Err   error
+}
+
+func newStepperError(field string, value float64, err error) *StepperError {
+	return &StepperError{
+		Field: field,
+		Value: value,
+		Err:   err,
+	}
+}
+
+// Error is implementation of error.
+func (e *StepperError) Error() string {
+	return fmt.Sprintf("%s %v: %v", e.Field, e.Value, e.Err)
+}
+
+// Unwrap returns the wrapped error.
+func (e *StepperError) Unwrap() error {
+	return e.Err
+}
+
+// Scale is the interface that groups the basic methods of steppers.
+// It's implemented by Stepper, GeometricStepper, DecadeStepper and TieredStepper.
+type Scale interface {
+	Prec() int
+	Base() int
+	Count64() int64
+	Step64(index int64) (float64, error)
+	Normalize(f float64) (float64, error)
+	Index(f float64) (int64, error)
+	Contains(f float64) bool
+}
+
 // Stepper is a utility to step and normalize floating point values by given precision and base.
 type Stepper struct {
 	prec       int
@@ -24,55 +75,104 @@ type Stepper struct {
 	maxReal    *Real
 	minReal    *Real
 	intrvlReal *Real
+	anchorReal *Real
 	step       float64
 	max        float64
 	min        float64
+	anchor     float64
 	count      int64
+	k          float64
 }
 
 // NewStepper returns a new Stepper with given precision, base and given step, max, min.
 // Both of max and min can be infinity. In this case, the range of Stepper is infinity.
+// If only one of max and min is infinity, the finite one is the step of index 0.
 // It panics unless base is in valid range.
 func NewStepper(prec, base int, step, max, min float64) (s *Stepper, err error) {
 	panicForInvalidBase(base)
 	s = &Stepper{
 		prec: prec,
 		base: base,
+		k:    math.Pow(float64(base), float64(prec)),
+	}
+	if !(step > 0) || math.IsInf(step, 0) {
+		return nil, newStepperError("step", step, ErrStepperStepOverflow)
 	}
 	s.stepReal = s.newReal().SetFloat64(step)
-	if f, acc := s.stepReal.Float64(); f != step || acc != big.Exact || math.IsInf(step, 0) {
-		return nil, ErrStepperStepOverflow
+	if f, acc := s.stepReal.Float64(); f != step || acc != big.Exact {
+		return nil, newStepperError("step", step, ErrStepperStepOverflow)
 	} else {
 		s.step = f
 	}
+	if math.IsNaN(max) {
+		return nil, newStepperError("max", max, ErrStepperMaxOverflow)
+	}
 	s.maxReal = s.newReal().SetFloat64(max)
 	if f, acc := s.maxReal.Float64(); f != max || acc != big.Exact || (!math.IsInf(max, 0) && math.Nextafter(max, math.Inf(+1))-max >= step) {
-		return nil, ErrStepperMaxOverflow
+		return nil, newStepperError("max", max, ErrStepperMaxOverflow)
 	} else {
 		s.max = f
 	}
+	if math.IsNaN(min) {
+		return nil, newStepperError("min", min, ErrStepperMinOverflow)
+	}
 	s.minReal = s.newReal().SetFloat64(min)
 	if f, acc := s.minReal.Float64(); f != min || acc != big.Exact || (!math.IsInf(min, 0) && min-math.Nextafter(min, math.Inf(-1)) >= step) {
-		return nil, ErrStepperMinOverflow
+		return nil, newStepperError("min", min, ErrStepperMinOverflow)
 	} else {
 		s.min = f
 	}
 	if s.maxReal.IsInf() && s.minReal.IsInf() && s.maxReal.Cmp(s.minReal) == 0 {
-		return nil, ErrStepperRangeOverflow
+		return nil, newStepperError("max", max, ErrStepperRangeOverflow)
 	}
 	s.intrvlReal = s.newReal().Sub(s.maxReal, s.minReal)
 	r := s.newReal().Quo(s.intrvlReal, s.stepReal)
 	if r.Cmp(s.newReal()) < 0 {
-		return nil, ErrStepperUnorderedMaxMin
+		return nil, newStepperError("max", max, ErrStepperUnorderedMaxMin)
 	}
 	if !r.IsInf() {
 		count, acc := r.Int64()
 		if !r.IsInt() || acc != big.Exact {
-			return nil, ErrStepperRangeOverflow
+			intrvl, _ := s.intrvlReal.Float64()
+			return nil, newStepperError("range", intrvl, ErrStepperRangeOverflow)
 		}
 		count++
 		s.count = count
 	}
+	s.anchorReal = s.newReal()
+	return s, nil
+}
+
+// NewAnchoredStepper is similar with NewStepper except that the step of index 0 is anchor if both of max and min are infinity.
+// If the range is infinity only on one side, the finite one of max and min must be aligned to anchor by step.
+// If the range isn't infinity, anchor is ignored.
+// It panics unless base is in valid range.
+func NewAnchoredStepper(prec, base int, step, max, min, anchor float64) (s *Stepper, err error) {
+	s, err = NewStepper(prec, base, step, max, min)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(anchor) || math.IsInf(anchor, 0) {
+		return nil, newStepperError("anchor", anchor, ErrStepperAnchorOverflow)
+	}
+	s.anchorReal = s.newReal().SetFloat64(anchor)
+	if f, acc := s.anchorReal.Float64(); f != anchor || acc != big.Exact {
+		return nil, newStepperError("anchor", anchor, ErrStepperAnchorOverflow)
+	} else {
+		s.anchor = f
+	}
+	if s.intrvlReal.IsInf() {
+		k := precRat(s.prec, s.base)
+		n, a := s.stepUnits(k), unitsOf(s.anchor, k)
+		for _, bound := range []float64{s.max, s.min} {
+			if math.IsInf(bound, 0) {
+				continue
+			}
+			if new(big.Int).Rem(new(big.Int).Sub(unitsOf(bound, k), a), n).Sign() != 0 {
+				return nil, newStepperError("anchor", anchor, ErrStepperAnchorOverflow)
+			}
+		}
+	}
 	return s, nil
 }
 
@@ -80,6 +180,25 @@ func (s *Stepper) newReal() *Real {
 	return NewReal(s.prec, s.base)
 }
 
+// stepperBuffer holds the values which are reused by computations of Stepper.
+type stepperBuffer struct {
+	x    *Real
+	f    *big.Float
+	i    *big.Int
+	half *big.Float
+	one  *big.Int
+}
+
+func (s *Stepper) newBuffer() *stepperBuffer {
+	return &stepperBuffer{
+		x:    s.newReal(),
+		f:    new(big.Float),
+		i:    new(big.Int),
+		half: big.NewFloat(0.5),
+		one:  big.NewInt(1),
+	}
+}
+
 // Prec returns precision of the Stepper.
 func (s *Stepper) Prec() int {
 	return s.prec
@@ -90,6 +209,11 @@ func (s *Stepper) Base() int {
 	return s.base
 }
 
+// Anchor returns the step of index 0 when both of max and min are infinity.
+func (s *Stepper) Anchor() float64 {
+	return s.anchor
+}
+
 // Count is same with Count64 if count is less than or equal to MaxIntValue.
 // If count is greater than MaxIntValue, it returns MaxIntValue.
 func (s *Stepper) Count() int {
@@ -100,54 +224,666 @@ func (s *Stepper) Count() int {
 }
 
 // Count64 returns number of step for given range.
-// If the range of Stepper is infinity, it returns 0.
+// If the range of Stepper is infinity, it returns 0. Unbounded should be used to check it.
 func (s *Stepper) Count64() int64 {
 	return s.count
 }
 
+// Unbounded checks the range of Stepper is infinity on any side.
+func (s *Stepper) Unbounded() bool {
+	return s.intrvlReal.IsInf()
+}
+
 // Step is same with Step64 except that Step indexes up to MaxIntValue.
 func (s *Stepper) Step(index int) (float64, error) {
 	return s.Step64(int64(index))
 }
 
 // Step64 returns proper step value by given index.
-// If the range of Stepper is infinity, step of index 0 is 0.
+// If only max is infinity, step of index 0 is min and indexes increase toward max.
+// If only min is infinity, step of index 0 is max and indexes decrease toward min.
+// If both of max and min are infinity, step of index 0 is the anchor which is 0 by default.
 func (s *Stepper) Step64(index int64) (float64, error) {
-	minReal := s.newReal()
-	if !s.intrvlReal.IsInf() {
-		if index >= s.count {
-			return s.max, ErrStepperMaxExceeded
-		}
-		if index < 0 {
-			return s.min, ErrStepperMinExceeded
-		}
-		minReal.Copy(s.minReal)
+	return s.step64(index, s.newBuffer())
+}
+
+func (s *Stepper) step64(index int64, b *stepperBuffer) (float64, error) {
+	if !s.maxReal.IsInf() && index > s.maxIndex() {
+		return s.max, ErrStepperMaxExceeded
+	}
+	if !s.minReal.IsInf() && index < 0 {
+		return s.min, ErrStepperMinExceeded
 	}
-	f, acc := s.newReal().Add(s.newReal().Mul(s.newReal().SetInt64(index), s.stepReal), minReal).Float64()
+	if f, ok := s.stepFloat(index); ok {
+		return f, nil
+	}
+	x := b.x.SetInt64(index)
+	x.Mul(x, s.stepReal)
+	x.Add(x, s.originReal())
+	return realFloat64(x)
+}
+
+// realFloat64 returns the float64 value of x.
+// It returns an error if x isn't exactly representable as float64.
+func realFloat64(x *Real) (float64, error) {
+	f, acc := x.Float64()
 	if acc != big.Exact {
-		panic("bug: result not exact")
+		switch {
+		case math.IsInf(f, +1):
+			return f, ErrStepperMaxExceeded
+		case math.IsInf(f, -1):
+			return f, ErrStepperMinExceeded
+		}
+		return f, ErrStepperInexact
 	}
 	return f, nil
 }
 
 // Normalize returns normalized float value by proper index.
-// If the range of Stepper is infinity, alignment of steps is made to be as to provide step of index 0 is 0.
+// If the range of Stepper is infinity, alignment of steps is made to be as to provide the step of index 0 like Step64.
 func (s *Stepper) Normalize(f float64) (float64, error) {
-	minReal := s.newReal()
-	if !s.intrvlReal.IsInf() {
-		if math.IsInf(f, +1) {
-			return f, ErrStepperMaxExceeded
-		}
-		if math.IsInf(f, -1) {
-			return f, ErrStepperMinExceeded
-		}
-		minReal.Copy(s.minReal)
+	return s.normalize(f, s.newBuffer())
+}
+
+func (s *Stepper) normalize(f float64, b *stepperBuffer) (float64, error) {
+	if math.IsNaN(f) {
+		return f, nil
+	}
+	if math.IsInf(f, +1) && !s.maxReal.IsInf() {
+		return f, ErrStepperMaxExceeded
+	}
+	if math.IsInf(f, -1) && !s.minReal.IsInf() {
+		return f, ErrStepperMinExceeded
 	}
 	if math.IsInf(f, 0) {
 		return f, nil
 	}
+	index, err := s.index(f, b)
+	switch err {
+	case ErrStepperMaxExceeded:
+		return s.max, err
+	case ErrStepperMinExceeded:
+		return s.min, err
+	}
+	return s.step64(index, b)
+}
+
+// Index returns index of the step which f is normalized to.
+// If the normalized step is out of range, it returns the index of the nearest bound with an error.
+// The step of index 0 is same with Step64.
+func (s *Stepper) Index(f float64) (int64, error) {
+	return s.index(f, s.newBuffer())
+}
+
+func (s *Stepper) index(f float64, b *stepperBuffer) (int64, error) {
 	if math.IsNaN(f) {
-		return f, nil
+		return 0, ErrStepperNaN
+	}
+	index, acc := int64(math.MaxInt64), big.Below
+	if math.IsInf(f, -1) {
+		index, acc = math.MinInt64, big.Above
+	} else if n, ok := s.indexFloat(f); ok {
+		index, acc = n, big.Exact
+	} else if !math.IsInf(f, +1) {
+		x := b.x.SetFloat64(f)
+		x.Sub(x, s.originReal())
+		x.Quo(x, s.stepReal)
+		b.f.Add(x.f, b.half)
+		n, a := b.f.Int(b.i)
+		if a == big.Above {
+			n.Sub(n, b.one)
+		}
+		index, acc = Int64BigInt(n)
+	}
+	if !s.maxReal.IsInf() && (index > s.maxIndex() || acc == big.Below) {
+		return s.maxIndex(), ErrStepperMaxExceeded
+	}
+	if !s.minReal.IsInf() && (index < 0 || acc == big.Above) {
+		return 0, ErrStepperMinExceeded
+	}
+	switch acc {
+	case big.Below:
+		return index, ErrStepperMaxExceeded
+	case big.Above:
+		return index, ErrStepperMinExceeded
+	}
+	return index, nil
+}
+
+// originReal returns the step of index 0 as Real.
+func (s *Stepper) originReal() *Real {
+	switch {
+	case !s.minReal.IsInf():
+		return s.minReal
+	case !s.maxReal.IsInf():
+		return s.maxReal
+	}
+	return s.anchorReal
+}
+
+// origin returns the step of index 0.
+func (s *Stepper) origin() float64 {
+	switch {
+	case !s.minReal.IsInf():
+		return s.min
+	case !s.maxReal.IsInf():
+		return s.max
+	}
+	return s.anchor
+}
+
+// maxIndex returns the index of max if max isn't infinity.
+func (s *Stepper) maxIndex() int64 {
+	if s.minReal.IsInf() {
+		return 0
+	}
+	return s.count - 1
+}
+
+// stepFloat is the fast path of step64 by float64 arithmetic.
+// It returns false unless the result is same with the result of Real arithmetic.
+func (s *Stepper) stepFloat(index int64) (float64, bool) {
+	x, ok := s.roundFloat(float64(index))
+	if ok {
+		x, ok = s.roundFloat(x * s.step)
+	}
+	if ok {
+		x, ok = s.roundFloat(x + s.origin())
+	}
+	return x, ok
+}
+
+// indexFloat is the fast path of index by float64 arithmetic.
+// It returns false unless the result is same with the result of Real arithmetic.
+func (s *Stepper) indexFloat(f float64) (int64, bool) {
+	x, ok := s.roundFloat(f)
+	if ok {
+		x, ok = s.roundFloat(x - s.origin())
+	}
+	if ok {
+		x, ok = s.roundFloat(x / s.step)
+	}
+	if !ok {
+		return 0, false
+	}
+	x = math.Floor(x + 0.5)
+	if !(-1<<63 < x && x < 1<<63) {
+		return 0, false
+	}
+	return int64(x), true
+}
+
+// roundFloat rounds x like Real by float64 arithmetic.
+// The arithmetic of big.Float with 53 bits precision is same with float64 arithmetic unless any value is subnormal or infinity.
+// So it returns false if any value is not normal or zero.
+func (s *Stepper) roundFloat(x float64) (float64, bool) {
+	if !isNormalFloat(s.k) || !isNormalFloat(x) {
+		return 0, false
+	}
+	y := x * s.k
+	if !isNormalFloat(y) {
+		return 0, false
+	}
+	y += 0.5
+	if !isNormalFloat(y) {
+		return 0, false
+	}
+	y = math.Floor(y) / s.k
+	return y, isNormalFloat(y)
+}
+
+// isNormalFloat checks x is zero or a normal floating point value.
+func isNormalFloat(x float64) bool {
+	if x == 0 {
+		return true
+	}
+	x = math.Abs(x)
+	return 2.2250738585072014e-308 <= x && x <= math.MaxFloat64
+}
+
+// NormalizeSlice normalizes each value of src into dst like Normalize, reusing internal values for all elements.
+// It returns the index of the first element which has an error and its error, or -1 and nil if there is no error.
+// It panics if dst is shorter than src.
+func (s *Stepper) NormalizeSlice(dst, src []float64) (first int, err error) {
+	dst = dst[:len(src)]
+	first = -1
+	b := s.newBuffer()
+	for i, f := range src {
+		var e error
+		dst[i], e = s.normalize(f, b)
+		if e != nil && err == nil {
+			first, err = i, e
+		}
+	}
+	return
+}
+
+// IndexSlice indexes each value of src into dst like Index, reusing internal values for all elements.
+// It returns the index of the first element which has an error and its error, or -1 and nil if there is no error.
+// It panics if dst is shorter than src.
+func (s *Stepper) IndexSlice(dst []int64, src []float64) (first int, err error) {
+	dst = dst[:len(src)]
+	first = -1
+	b := s.newBuffer()
+	for i, f := range src {
+		var e error
+		dst[i], e = s.index(f, b)
+		if e != nil && err == nil {
+			first, err = i, e
+		}
+	}
+	return
+}
+
+// Contains checks f is exactly one of the steps of Stepper.
+func (s *Stepper) Contains(f float64) bool {
+	return containsStep(s, f)
+}
+
+func containsStep(s Scale, f float64) bool {
+	index, err := s.Index(f)
+	if err != nil {
+		return false
+	}
+	g, err := s.Step64(index)
+	return err == nil && g == f
+}
+
+// Rand returns a uniformly random step of Stepper by drawing an index from r.
+// If r is nil, it uses the cryptographically secure random number generator like CryptoRandInt.
+// It returns ErrStepperRangeOverflow if the range of Stepper is infinity.
+func (s *Stepper) Rand(r io.Reader) (float64, error) {
+	if s.Unbounded() {
+		return 0, ErrStepperRangeOverflow
+	}
+	if r == nil {
+		r = crand.Reader
+	}
+	index, err := crand.Int(r, s.CountBig())
+	if err != nil {
+		return 0, err
+	}
+	return s.bigStep(index)
+}
+
+// CountBig is same with Count64 except that CountBig returns big.Int.
+// If the range of Stepper is infinity, it returns nil.
+//
+// The big.Int methods are only useful for the indexes beyond int64 of unbounded steppers.
+// The count of finite range always fits in int64, because max and min must be accurate to step in float64.
+// So NewStepper rejects a fine step over a wide finite range with ErrStepperMaxOverflow or ErrStepperMinOverflow.
+func (s *Stepper) CountBig() *big.Int {
+	if s.Unbounded() {
+		return nil
+	}
+	return big.NewInt(s.count)
+}
+
+// StepBig is same with Step64 except that StepBig indexes beyond int64.
+// It returns the nearest float64 value with ErrStepperInexact if the step beyond int64 isn't exactly representable as float64.
+func (s *Stepper) StepBig(index *big.Int) (float64, error) {
+	if !s.maxReal.IsInf() && index.Cmp(s.maxIndexBig()) > 0 {
+		return s.max, ErrStepperMaxExceeded
+	}
+	if !s.minReal.IsInf() && index.Sign() < 0 {
+		return s.min, ErrStepperMinExceeded
+	}
+	return s.bigStep(index)
+}
+
+// IndexBig is same with Index except that IndexBig indexes beyond int64 in infinite ranges.
+// In finite ranges, it is same with Index.
+func (s *Stepper) IndexBig(f float64) (*big.Int, error) {
+	index, err := s.Index(f)
+	if math.IsInf(f, 0) || !((err == ErrStepperMaxExceeded && s.maxReal.IsInf()) || (err == ErrStepperMinExceeded && s.minReal.IsInf())) {
+		return big.NewInt(index), err
+	}
+	k := precRat(s.prec, s.base)
+	q := new(big.Rat).SetInt(new(big.Int).Sub(unitsOf(f, k), s.originUnits(k)))
+	q.Quo(q, new(big.Rat).SetInt(s.stepUnits(k)))
+	return FloorBigRat(q.Add(q, big.NewRat(1, 2))), nil
+}
+
+// maxIndexBig returns the index of max as big.Int if max isn't infinity.
+func (s *Stepper) maxIndexBig() *big.Int {
+	return big.NewInt(s.maxIndex())
+}
+
+// bigStep returns the step by given index as big.Int without checking range.
+// If index is beyond int64, it computes the step exactly in units of precision.
+// It returns an error if the step overflows float64 or isn't exactly representable as float64.
+func (s *Stepper) bigStep(index *big.Int) (float64, error) {
+	if index.IsInt64() {
+		return s.step64(index.Int64(), s.newBuffer())
+	}
+	k := precRat(s.prec, s.base)
+	i := new(big.Int).Mul(index, s.stepUnits(k))
+	i.Add(i, s.originUnits(k))
+	f, exact := new(big.Rat).Quo(new(big.Rat).SetInt(i), k).Float64()
+	switch {
+	case math.IsInf(f, +1):
+		return f, ErrStepperMaxExceeded
+	case math.IsInf(f, -1):
+		return f, ErrStepperMinExceeded
+	case !exact:
+		return f, ErrStepperInexact
+	}
+	return f, nil
+}
+
+// Distance returns number of steps from a to b after normalizing both of them.
+// The result is positive if b is greater than a.
+// It returns ErrStepperRangeOverflow if the result overflows int64.
+func (s *Stepper) Distance(a, b float64) (int64, error) {
+	i, err := s.IndexBig(a)
+	if err != nil {
+		return 0, err
+	}
+	j, err := s.IndexBig(b)
+	if err != nil {
+		return 0, err
+	}
+	d, acc := Int64BigInt(j.Sub(j, i))
+	if acc != big.Exact {
+		return 0, ErrStepperRangeOverflow
+	}
+	return d, nil
+}
+
+// Offset returns the step which is n steps away from the step which f is normalized to.
+// If f is out of range, it returns the result of Normalize.
+// If the result is out of range, it returns the nearest bound with an error.
+func (s *Stepper) Offset(f float64, n int64) (float64, error) {
+	index, err := s.IndexBig(f)
+	if err != nil {
+		g, _ := s.Normalize(f)
+		return g, err
+	}
+	return s.StepBig(index.Add(index, big.NewInt(n)))
+}
+
+// Next returns the least step which is greater than f.
+// If there is no such step, it returns max with ErrStepperMaxExceeded.
+func (s *Stepper) Next(f float64) (float64, error) {
+	return s.adjacent(f, +1)
+}
+
+// Prev returns the greatest step which is less than f.
+// If there is no such step, it returns min with ErrStepperMinExceeded.
+func (s *Stepper) Prev(f float64) (float64, error) {
+	return s.adjacent(f, -1)
+}
+
+func (s *Stepper) adjacent(f float64, n int64) (float64, error) {
+	index, err := s.IndexBig(f)
+	switch {
+	case err == ErrStepperNaN:
+		return f, err
+	case err == ErrStepperMaxExceeded && s.maxReal.IsInf():
+		return f, err
+	case err == ErrStepperMinExceeded && s.minReal.IsInf():
+		return f, err
+	}
+	g, err := s.StepBig(index)
+	if err != nil {
+		return g, err
+	}
+	if (n > 0 && g > f) || (n < 0 && g < f) {
+		return g, nil
+	}
+	return s.StepBig(index.Add(index, big.NewInt(n)))
+}
+
+// Slice returns a new Stepper which has the steps of Stepper from minIndex to maxIndex.
+// The arguments are in the order of maxIndex and minIndex like max and min of NewStepper.
+// It returns ErrStepperUnorderedMaxMin if maxIndex is less than minIndex.
+// The indexes of an infinite range are relative to step 0.
+func (s *Stepper) Slice(maxIndex, minIndex int64) (*Stepper, error) {
+	if maxIndex < minIndex {
+		return nil, ErrStepperUnorderedMaxMin
+	}
+	max, err := s.Step64(maxIndex)
+	if err != nil {
+		return nil, err
+	}
+	min, err := s.Step64(minIndex)
+	if err != nil {
+		return nil, err
+	}
+	return NewStepper(s.prec, s.base, s.step, max, min)
+}
+
+// Restrict returns a new Stepper which has the steps of Stepper in the range between max and min.
+// The arguments are in the order of max and min like NewStepper.
+// The range of the result is narrowed to the range of Stepper, and aligned to the steps of Stepper.
+// It returns StepperError with ErrStepperUnorderedMaxMin if no step is between max and min, such as if max is less than min.
+// Both of max and min can be infinity.
+func (s *Stepper) Restrict(max, min float64) (*Stepper, error) {
+	if math.IsNaN(max) {
+		return nil, ErrStepperMaxOverflow
+	}
+	if math.IsNaN(min) {
+		return nil, ErrStepperMinOverflow
+	}
+	k := precRat(s.prec, s.base)
+	origin, n := s.originUnits(k), s.stepUnits(k)
+	max = alignUnits(math.Min(max, s.max), k, origin, n, false)
+	min = alignUnits(math.Max(min, s.min), k, origin, n, true)
+	return NewAnchoredStepper(s.prec, s.base, s.step, max, min, s.origin())
+}
+
+// CommonStepper returns a new Stepper which has the common steps of x and y.
+// The step of the result is the least common multiple of steps of x and y, and the range is the intersection of ranges.
+// It returns ErrStepperIncompatible if x and y have different precision or base, or they have no common step.
+func CommonStepper(x, y *Stepper) (*Stepper, error) {
+	if x.prec != y.prec || x.base != y.base {
+		return nil, ErrStepperIncompatible
+	}
+	k := precRat(x.prec, x.base)
+	na, nb := x.stepUnits(k), y.stepUnits(k)
+	oa, ob := x.originUnits(k), y.originUnits(k)
+	g := new(big.Int).GCD(nil, nil, na, nb)
+	d, r := new(big.Int).QuoRem(new(big.Int).Sub(ob, oa), g, new(big.Int))
+	if r.Sign() != 0 {
+		return nil, ErrStepperIncompatible
+	}
+	l := new(big.Int).Mul(new(big.Int).Quo(na, g), nb)
+	m := new(big.Int).Quo(nb, g)
+	t := new(big.Int)
+	if m.Cmp(big.NewInt(1)) != 0 {
+		t.ModInverse(new(big.Int).Quo(na, g), m)
+		t.Mul(t, d)
+		t.Mod(t, m)
+	}
+	origin := new(big.Int).Add(oa, t.Mul(t, na))
+	step, _ := new(big.Rat).Quo(new(big.Rat).SetInt(l), k).Float64()
+	anchor, _ := new(big.Rat).Quo(new(big.Rat).SetInt(origin), k).Float64()
+	max := alignUnits(math.Min(x.max, y.max), k, origin, l, false)
+	min := alignUnits(math.Max(x.min, y.min), k, origin, l, true)
+	return NewAnchoredStepper(x.prec, x.base, step, max, min, anchor)
+}
+
+// stepUnits returns the step in units of precision.
+func (s *Stepper) stepUnits(k *big.Rat) *big.Int {
+	return unitsOf(s.step, k)
+}
+
+// originUnits returns the step of index 0 in units of precision.
+func (s *Stepper) originUnits(k *big.Rat) *big.Int {
+	return unitsOf(s.origin(), k)
+}
+
+// precRat returns base^prec which is the number of units of precision in 1.
+func precRat(prec, base int) *big.Rat {
+	if prec < 0 {
+		return new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Exp(big.NewInt(int64(base)), big.NewInt(int64(-prec)), nil))
+	}
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(int64(base)), big.NewInt(int64(prec)), nil))
+}
+
+// unitsOf returns f in units of precision, rounding half up like Real.
+func unitsOf(f float64, k *big.Rat) *big.Int {
+	q := new(big.Rat).Mul(new(big.Rat).SetFloat64(f), k)
+	return FloorBigRat(q.Add(q, big.NewRat(1, 2)))
+}
+
+// alignUnits returns the nearest step to f in the direction of ceil or floor on the grid given by origin and n in units of precision.
+// f is rounded by precision before aligning. It returns f if f is infinity.
+func alignUnits(f float64, k *big.Rat, origin, n *big.Int, ceil bool) float64 {
+	if math.IsInf(f, 0) {
+		return f
+	}
+	q := new(big.Rat).SetInt(new(big.Int).Sub(unitsOf(f, k), origin))
+	q.Quo(q, new(big.Rat).SetInt(n))
+	var i *big.Int
+	if ceil {
+		i = CeilBigRat(q)
+	} else {
+		i = FloorBigRat(q)
+	}
+	i.Mul(i, n)
+	i.Add(i, origin)
+	result, _ := new(big.Rat).Quo(new(big.Rat).SetInt(i), k).Float64()
+	return result
+}
+
+// stepperJSON is the JSON representation of Stepper.
+// The required fields are pointers to detect their absence.
+type stepperJSON struct {
+	Prec   *int       `json:"prec"`
+	Base   *int       `json:"base"`
+	Step   *jsonFloat `json:"step"`
+	Max    *jsonFloat `json:"max"`
+	Min    *jsonFloat `json:"min"`
+	Anchor jsonFloat  `json:"anchor"`
+}
+
+// jsonFloat is a float64 which is encoded to a JSON number, or a JSON string for infinity.
+type jsonFloat float64
+
+func (x jsonFloat) MarshalJSON() ([]byte, error) {
+	text := formatStepperFloat(float64(x))
+	if math.IsInf(float64(x), 0) {
+		return json.Marshal(text)
+	}
+	return []byte(text), nil
+}
+
+func (x *jsonFloat) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if strings.HasPrefix(text, `"`) {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return err
+	}
+	*x = jsonFloat(f)
+	return nil
+}
+
+// MarshalJSON is implementation of json.Marshaler.
+// The values of step, max, min and anchor are encoded to the shortest decimal representations which are exactly same with them.
+// Infinities are encoded to the strings "+Inf" and "-Inf".
+func (s *Stepper) MarshalJSON() ([]byte, error) {
+	prec, base := s.prec, s.base
+	step, max, min := jsonFloat(s.step), jsonFloat(s.max), jsonFloat(s.min)
+	return json.Marshal(&stepperJSON{
+		Prec:   &prec,
+		Base:   &base,
+		Step:   &step,
+		Max:    &max,
+		Min:    &min,
+		Anchor: jsonFloat(s.anchor),
+	})
+}
+
+// UnmarshalJSON is implementation of json.Unmarshaler.
+// The field anchor is optional, and the others are required. It returns ErrStepperInvalidText if any required field is missing or null.
+// It validates the values like NewAnchoredStepper, and returns StepperError instead of panicking for invalid base.
+// It returns StepperError with ErrStepperInvalidText if base^prec isn't a normal float64 value.
+func (s *Stepper) UnmarshalJSON(data []byte) error {
+	var v stepperJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
 	}
-	return s.Step64(RoundBigFloat(s.newReal().Quo(s.newReal().Sub(s.newReal().SetFloat64(f), minReal), s.stepReal).Float()).Int64())
+	if v.Prec == nil || v.Base == nil || v.Step == nil || v.Max == nil || v.Min == nil {
+		return ErrStepperInvalidText
+	}
+	return s.set(*v.Prec, *v.Base, float64(*v.Step), float64(*v.Max), float64(*v.Min), float64(v.Anchor))
+}
+
+// MarshalText is implementation of encoding.TextMarshaler.
+// The text is in the form "prec=2 base=10 step=0.25 max=+Inf min=-5 anchor=0".
+func (s *Stepper) MarshalText() (text []byte, err error) {
+	return []byte(fmt.Sprintf("prec=%d base=%d step=%s max=%s min=%s anchor=%s",
+		s.prec, s.base, formatStepperFloat(s.step), formatStepperFloat(s.max), formatStepperFloat(s.min), formatStepperFloat(s.anchor))), nil
+}
+
+// UnmarshalText is implementation of encoding.TextUnmarshaler.
+// The field anchor is optional, and the others are required.
+// It validates the values like NewAnchoredStepper, and returns StepperError instead of panicking for invalid base.
+// It returns StepperError with ErrStepperInvalidText if base^prec isn't a normal float64 value.
+func (s *Stepper) UnmarshalText(text []byte) error {
+	var prec, base int64
+	var step, max, min, anchor float64
+	found := make(map[string]bool, 6)
+	for _, field := range strings.Fields(string(text)) {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 || found[kv[0]] {
+			return ErrStepperInvalidText
+		}
+		var err error
+		switch kv[0] {
+		case "prec":
+			prec, err = strconv.ParseInt(kv[1], 10, 0)
+		case "base":
+			base, err = strconv.ParseInt(kv[1], 10, 0)
+		case "step":
+			step, err = strconv.ParseFloat(kv[1], 64)
+		case "max":
+			max, err = strconv.ParseFloat(kv[1], 64)
+		case "min":
+			min, err = strconv.ParseFloat(kv[1], 64)
+		case "anchor":
+			anchor, err = strconv.ParseFloat(kv[1], 64)
+		default:
+			return ErrStepperInvalidText
+		}
+		if err != nil {
+			return ErrStepperInvalidText
+		}
+		found[kv[0]] = true
+	}
+	for _, key := range []string{"prec", "base", "step", "max", "min"} {
+		if !found[key] {
+			return ErrStepperInvalidText
+		}
+	}
+	return s.set(int(prec), int(base), step, max, min, anchor)
+}
+
+func (s *Stepper) set(prec, base int, step, max, min, anchor float64) error {
+	if !(MinBase <= base && base <= MaxBase) {
+		return newStepperError("base", float64(base), ErrStepperInvalidBase)
+	}
+	if k := math.Pow(float64(base), float64(prec)); k == 0 || !isNormalFloat(k) {
+		return newStepperError("prec", float64(prec), ErrStepperInvalidText)
+	}
+	x, err := NewAnchoredStepper(prec, base, step, max, min, anchor)
+	if err != nil {
+		return err
+	}
+	*s = *x
+	return nil
+}
+
+// formatStepperFloat returns the shortest decimal representation of f which is exactly same with f.
+func formatStepperFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
 }
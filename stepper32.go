@@ -0,0 +1,116 @@
+package xmath
+
+import (
+	"math"
+)
+
+// Stepper32 is a companion of Stepper to step and normalize float32 values by given precision and base.
+// The results are computed like Stepper, and they must be accurate to precision in float32.
+type Stepper32 struct {
+	s *Stepper
+}
+
+// NewStepper32 returns a new Stepper32 with given precision, base and given step, max, min like NewStepper.
+// The values are converted to the float64 values which are rounded by precision.
+// Both of max and min must be accurate to step in float32.
+// It panics unless base is in valid range.
+func NewStepper32(prec, base int, step, max, min float32) (*Stepper32, error) {
+	s, err := NewStepper(prec, base, float64From32(prec, base, step), float64From32(prec, base, max), float64From32(prec, base, min))
+	if err != nil {
+		return nil, err
+	}
+	if !math.IsInf(float64(max), 0) && float64(math.Nextafter32(max, float32(math.Inf(+1)))-max) >= s.step {
+		return nil, newStepperError("max", s.max, ErrStepperMaxOverflow)
+	}
+	if !math.IsInf(float64(min), 0) && float64(min-math.Nextafter32(min, float32(math.Inf(-1)))) >= s.step {
+		return nil, newStepperError("min", s.min, ErrStepperMinOverflow)
+	}
+	return &Stepper32{s: s}, nil
+}
+
+// Stepper returns the underlying Stepper.
+func (s *Stepper32) Stepper() *Stepper {
+	return s.s
+}
+
+// Prec returns precision of the Stepper32.
+func (s *Stepper32) Prec() int {
+	return s.s.Prec()
+}
+
+// Base returns base of the Stepper32.
+func (s *Stepper32) Base() int {
+	return s.s.Base()
+}
+
+// Count is same with Count64 if count is less than or equal to MaxIntValue.
+// If count is greater than MaxIntValue, it returns MaxIntValue.
+func (s *Stepper32) Count() int {
+	return s.s.Count()
+}
+
+// Count64 returns number of step for given range.
+// If the range of Stepper32 is infinity, it returns 0.
+func (s *Stepper32) Count64() int64 {
+	return s.s.Count64()
+}
+
+// Step is same with Step64 except that Step indexes up to MaxIntValue.
+func (s *Stepper32) Step(index int) (float32, error) {
+	return s.Step64(int64(index))
+}
+
+// Step64 returns proper step value by given index like Stepper.
+// It returns ErrStepperInexact if the step isn't accurate to precision in float32.
+func (s *Stepper32) Step64(index int64) (float32, error) {
+	return s.float32(s.s.Step64(index))
+}
+
+// Normalize returns normalized float value by proper index like Stepper.
+// It returns ErrStepperInexact if the result isn't accurate to precision in float32.
+func (s *Stepper32) Normalize(f float32) (float32, error) {
+	return s.float32(s.s.Normalize(float64(f)))
+}
+
+// Index returns index of the step which f is normalized to like Stepper.
+func (s *Stepper32) Index(f float32) (int64, error) {
+	return s.s.Index(float64(f))
+}
+
+// Contains checks f is exactly one of the steps of Stepper32.
+func (s *Stepper32) Contains(f float32) bool {
+	index, err := s.Index(f)
+	if err != nil {
+		return false
+	}
+	g, err := s.Step64(index)
+	return err == nil && g == f
+}
+
+// float32 converts the result f of Stepper to float32.
+// It keeps the error err of Stepper if it isn't nil.
+func (s *Stepper32) float32(f float64, err error) (float32, error) {
+	g := float32(f)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return g, err
+	}
+	switch {
+	case math.IsInf(float64(g), +1):
+		return g, ErrStepperMaxExceeded
+	case math.IsInf(float64(g), -1):
+		return g, ErrStepperMinExceeded
+	}
+	if float64From32(s.s.prec, s.s.base, g) != f {
+		return g, ErrStepperInexact
+	}
+	return g, nil
+}
+
+// float64From32 returns the float64 value of f which is rounded by precision.
+func float64From32(prec, base int, f float32) float64 {
+	if math.IsNaN(float64(f)) || math.IsInf(float64(f), 0) {
+		return float64(f)
+	}
+	g, _ := NewReal(prec, base).SetFloat64(float64(f)).Float64()
+	return g
+}
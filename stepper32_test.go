@@ -0,0 +1,56 @@
+package xmath_test
+
+import (
+	"fmt"
+
+	"github.com/goinsane/xmath"
+)
+
+func ExampleNewStepper32() {
+	var err error
+	_, err = xmath.NewStepper32(2, 10, 0.05, 100, -100)
+	fmt.Println(err)
+	_, err = xmath.NewStepper32(2, 10, 0.05, 1e6, -100)
+	fmt.Println(err)
+	_, err = xmath.NewStepper32(2, 10, 0.05, 100, -1e6)
+	fmt.Println(err)
+	_, err = xmath.NewStepper32(2, 10, 0.05, 100.02, -100)
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// max 1e+06: max overflow
+	// min -1e+06: min overflow
+	// range 200.02: range overflow
+}
+
+func ExampleStepper32_Normalize() {
+	s, err := xmath.NewStepper32(2, 10, 0.05, 100, -100)
+	if err != nil {
+		panic(err)
+	}
+	for _, f := range []float32{0.1, 0.12, 0.13, 33.33, -99.99, 100.1, -101} {
+		fmt.Println(s.Normalize(f))
+	}
+	fmt.Println(s.Step(2001))
+	fmt.Println(s.Contains(0.15), s.Contains(0.16))
+	s, err = xmath.NewStepper32(9, 10, 0.001, 1, 0)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s.Normalize(0.5))
+	fmt.Println(s.Normalize(0.123))
+
+	// Output:
+	// 0.1 <nil>
+	// 0.1 <nil>
+	// 0.15 <nil>
+	// 33.35 <nil>
+	// -100 <nil>
+	// 100 max exceeded
+	// -100 min exceeded
+	// 0.05 <nil>
+	// true false
+	// 0.5 <nil>
+	// 0.123 inexact result
+}
@@ -1,12 +1,130 @@
 package xmath_test
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"math/big"
+	"math/rand"
+	"testing"
 
 	"github.com/goinsane/xmath"
 )
 
+func newBenchmarkStepperValues(b *testing.B) (*xmath.Stepper, []float64) {
+	s, err := xmath.NewStepper(2, 10, 0.05, 1000, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	src := make([]float64, 10000)
+	for i := range src {
+		src[i] = xmath.CryptoRandFloat() * 1000
+	}
+	return s, src
+}
+
+func BenchmarkStepper_Normalize(b *testing.B) {
+	s, src := newBenchmarkStepperValues(b)
+	dst := make([]float64, len(src))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j, f := range src {
+			dst[j], _ = s.Normalize(f)
+		}
+	}
+}
+
+func BenchmarkStepper_NormalizeSlice(b *testing.B) {
+	s, src := newBenchmarkStepperValues(b)
+	dst := make([]float64, len(src))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.NormalizeSlice(dst, src)
+	}
+}
+
+func BenchmarkStepper_IndexSlice(b *testing.B) {
+	s, src := newBenchmarkStepperValues(b)
+	dst := make([]int64, len(src))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.IndexSlice(dst, src)
+	}
+}
+
+// TestStepper_fastPath checks the results of the float64 fast path are same with Real arithmetic, including ties and bounds.
+func TestStepper_fastPath(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	bases := []int{2, 3, 8, 10, 16}
+	for n := 0; n < 2000; n++ {
+		prec, base := r.Intn(10)-3, bases[r.Intn(len(bases))]
+		round := func(f float64) float64 {
+			g, _ := xmath.NewReal(prec, base).SetFloat64(f).Float64()
+			return g
+		}
+		unit := math.Pow(float64(base), float64(-prec))
+		step := round(float64(1+r.Intn(999)) * unit)
+		min := round(float64(r.Intn(2000001)-1000000) * unit)
+		max := round(min + float64(r.Intn(1000000))*step)
+		if r.Intn(10) == 0 {
+			max = math.Inf(+1)
+		}
+		s, err := xmath.NewStepper(prec, base, step, max, min)
+		if err != nil {
+			continue
+		}
+		stepReal, minReal := xmath.NewReal(prec, base).SetFloat64(step), xmath.NewReal(prec, base).SetFloat64(min)
+		stepRef := func(index int64) (float64, bool) {
+			x := xmath.NewReal(prec, base).SetInt64(index)
+			x.Mul(x, stepReal)
+			x.Add(x, minReal)
+			f, acc := x.Float64()
+			return f, acc == big.Exact
+		}
+		indexRef := func(f float64) int64 {
+			x := xmath.NewReal(prec, base).SetFloat64(f)
+			x.Sub(x, minReal)
+			x.Quo(x, stepReal)
+			y := new(big.Float).Add(x.Float(), big.NewFloat(0.5))
+			i, acc := y.Int(nil)
+			if acc == big.Above {
+				i.Sub(i, big.NewInt(1))
+			}
+			return i.Int64()
+		}
+		last := int64(math.MaxInt64)
+		if !s.Unbounded() {
+			last = s.Count64() - 1
+		}
+		values := []float64{min, math.Nextafter(min, math.Inf(+1)), math.Nextafter(min, math.Inf(-1)), min - step/2, min + step/2}
+		if !s.Unbounded() {
+			values = append(values, max, math.Nextafter(max, math.Inf(+1)), math.Nextafter(max, math.Inf(-1)), max-step/2, max+step/2)
+		}
+		for i := 0; i < 20; i++ {
+			index := r.Int63n(1000001)
+			if f, ok := stepRef(index); ok {
+				values = append(values, f, f+step/2, f-step/2, f+r.Float64()*step)
+			}
+			if got, err := s.Step64(index); index <= last {
+				if want, ok := stepRef(index); ok && (got != want || err != nil) {
+					t.Fatalf("prec=%d base=%d step=%v max=%v min=%v: Step64(%d) = %v, %v; want %v", prec, base, step, max, min, index, got, err, want)
+				}
+			}
+		}
+		for _, f := range values {
+			want := indexRef(f)
+			got, err := s.Index(f)
+			if 0 <= want && want <= last && (got != want || err != nil) {
+				t.Fatalf("prec=%d base=%d step=%v max=%v min=%v: Index(%v) = %d, %v; want %d", prec, base, step, max, min, f, got, err, want)
+			}
+			if (want < 0 || want > last) && err == nil {
+				t.Fatalf("prec=%d base=%d step=%v max=%v min=%v: Index(%v) = %d; want error", prec, base, step, max, min, f, got)
+			}
+		}
+	}
+}
+
 func ExampleNewStepper() {
 	var err error
 	_, err = xmath.NewStepper(2, 10, 0.1, 3.01, 2.31)
@@ -28,13 +146,13 @@ func ExampleNewStepper() {
 
 	// Output:
 	// <nil>
-	// step overflow
-	// max overflow
-	// min overflow
-	// range overflow
-	// unordered max min
+	// step 0.105: step overflow
+	// max 3.015: max overflow
+	// min 2.315: min overflow
+	// range 0.7: range overflow
+	// max 2.31: unordered max min
 	// <nil>
-	// unordered max min
+	// max -Inf: unordered max min
 }
 
 func ExampleStepper_Step() {
@@ -85,22 +203,19 @@ func ExampleStepper_Step_inf_max() {
 	if err != nil {
 		panic(err)
 	}
-	for i := -5; i <= +5; i++ {
+	fmt.Println(s.Unbounded(), s.Count())
+	for i := -2; i <= +3; i++ {
 		fmt.Println(s.Step(i))
 	}
 
 	// Output:
-	// -0.625 <nil>
-	// -0.5 <nil>
-	// -0.375 <nil>
-	// -0.25 <nil>
-	// -0.125 <nil>
-	// 0 <nil>
-	// 0.125 <nil>
-	// 0.25 <nil>
-	// 0.375 <nil>
-	// 0.5 <nil>
-	// 0.625 <nil>
+	// true 0
+	// -5 min exceeded
+	// -5 min exceeded
+	// -5 <nil>
+	// -4.875 <nil>
+	// -4.75 <nil>
+	// -4.625 <nil>
 }
 
 func ExampleStepper_Step_inf_min() {
@@ -108,22 +223,39 @@ func ExampleStepper_Step_inf_min() {
 	if err != nil {
 		panic(err)
 	}
-	for i := -5; i <= +5; i++ {
+	fmt.Println(s.Unbounded(), s.Count())
+	for i := -3; i <= +2; i++ {
 		fmt.Println(s.Step(i))
 	}
 
 	// Output:
-	// -0.625 <nil>
-	// -0.5 <nil>
-	// -0.375 <nil>
-	// -0.25 <nil>
-	// -0.125 <nil>
-	// 0 <nil>
-	// 0.125 <nil>
-	// 0.25 <nil>
-	// 0.375 <nil>
-	// 0.5 <nil>
-	// 0.625 <nil>
+	// true 0
+	// -5.375 <nil>
+	// -5.25 <nil>
+	// -5.125 <nil>
+	// -5 <nil>
+	// -5 max exceeded
+	// -5 max exceeded
+}
+
+func ExampleStepper_Normalize_inf_max() {
+	s, err := xmath.NewStepper(2, 10, 0.25, math.Inf(+1), 1.1)
+	if err != nil {
+		panic(err)
+	}
+	for _, f := range []float64{math.Inf(-1), 0.9, 0.98, 1.3, 1e6, math.Inf(+1)} {
+		n, err := s.Normalize(f)
+		i, _ := s.Index(f)
+		fmt.Println(n, i, err)
+	}
+
+	// Output:
+	// -Inf 0 min exceeded
+	// 1.1 0 min exceeded
+	// 1.1 0 <nil>
+	// 1.35 1 <nil>
+	// 1.0000001e+06 3999996 <nil>
+	// +Inf 9223372036854775807 <nil>
 }
 
 func ExampleStepper_Normalize() {
@@ -144,3 +276,422 @@ func ExampleStepper_Normalize() {
 	// -6.25 <nil>
 	// -6.25 <nil>
 }
+
+func ExampleStepper_Index() {
+	s, err := xmath.NewStepper(2, 10, 0.25, -5.00, -7.00)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s.Index(0.50))
+	fmt.Println(s.Index(-7.75))
+	fmt.Println(s.Index(-6.376))
+	fmt.Println(s.Index(-6.375))
+	fmt.Println(s.Index(math.NaN()))
+
+	// Output:
+	// 8 max exceeded
+	// 0 min exceeded
+	// 2 <nil>
+	// 3 <nil>
+	// 0 NaN value
+}
+
+func ExampleStepper_Contains() {
+	s, err := xmath.NewStepper(2, 10, 0.25, -5.00, -7.00)
+	if err != nil {
+		panic(err)
+	}
+	var sc xmath.Scale = s
+	fmt.Println(sc.Contains(-6.5))
+	fmt.Println(sc.Contains(-6.4))
+	fmt.Println(sc.Contains(-7.25))
+	fmt.Println(sc.Contains(math.NaN()))
+
+	// Output:
+	// true
+	// false
+	// false
+	// false
+}
+
+func ExampleStepper_Slice() {
+	s, err := xmath.NewStepper(2, 10, 0.25, 5, -5)
+	if err != nil {
+		panic(err)
+	}
+	r, err := s.Slice(12, 8)
+	if err != nil {
+		panic(err)
+	}
+	for i := 0; i < r.Count(); i++ {
+		fmt.Println(r.Step(i))
+	}
+	_, err = s.Slice(8, 12)
+	fmt.Println(err)
+	_, err = s.Slice(41, 8)
+	fmt.Println(err)
+
+	// Output:
+	// -3 <nil>
+	// -2.75 <nil>
+	// -2.5 <nil>
+	// -2.25 <nil>
+	// -2 <nil>
+	// unordered max min
+	// max exceeded
+}
+
+func ExampleStepper_Restrict() {
+	s, err := xmath.NewStepper(2, 10, 0.25, 5.1, -4.9)
+	if err != nil {
+		panic(err)
+	}
+	r, err := s.Restrict(1.2, 0.2)
+	if err != nil {
+		panic(err)
+	}
+	for i := 0; i < r.Count(); i++ {
+		fmt.Println(r.Step(i))
+	}
+	r, err = s.Restrict(math.Inf(+1), 4.5)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(r.Count())
+	_, err = s.Restrict(0.3, 0.2)
+	fmt.Println(err)
+	_, err = s.Restrict(0.2, 1.2)
+	fmt.Println(err)
+
+	// Output:
+	// 0.35 <nil>
+	// 0.6 <nil>
+	// 0.85 <nil>
+	// 1.1 <nil>
+	// 3
+	// max 0.1: unordered max min
+	// max 0.1: unordered max min
+}
+
+func ExampleCommonStepper() {
+	x, err := xmath.NewStepper(2, 10, 0.04, 2, 0)
+	if err != nil {
+		panic(err)
+	}
+	y, err := xmath.NewStepper(2, 10, 0.06, 3.04, 0.04)
+	if err != nil {
+		panic(err)
+	}
+	c, err := xmath.CommonStepper(x, y)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(c.Count())
+	fmt.Println(c.Step(0))
+	fmt.Println(c.Step(1))
+	fmt.Println(c.Step(c.Count() - 1))
+	z, err := xmath.NewStepper(2, 10, 0.02, 3.01, 0.01)
+	if err != nil {
+		panic(err)
+	}
+	_, err = xmath.CommonStepper(x, z)
+	fmt.Println(err)
+	w, err := xmath.NewStepper(3, 10, 0.04, 2, 0)
+	if err != nil {
+		panic(err)
+	}
+	_, err = xmath.CommonStepper(x, w)
+	fmt.Println(err)
+
+	// Output:
+	// 17
+	// 0.04 <nil>
+	// 0.16 <nil>
+	// 1.96 <nil>
+	// incompatible steppers
+	// incompatible steppers
+}
+
+func ExampleStepper_NormalizeSlice() {
+	s, err := xmath.NewStepper(2, 10, 0.25, -5.00, -7.00)
+	if err != nil {
+		panic(err)
+	}
+	src := []float64{-6.376, -6.375, 0.50, -7.75}
+	dst := make([]float64, len(src))
+	fmt.Println(s.NormalizeSlice(dst, src))
+	fmt.Println(dst)
+	idx := make([]int64, len(src))
+	fmt.Println(s.IndexSlice(idx, src[:2]))
+	fmt.Println(idx)
+
+	// Output:
+	// 2 max exceeded
+	// [-6.5 -6.25 -5 -7]
+	// -1 <nil>
+	// [2 3 0 0]
+}
+
+func ExampleNewAnchoredStepper() {
+	var err error
+	_, err = xmath.NewAnchoredStepper(2, 10, 0.5, math.Inf(+1), math.Inf(-1), 0.25)
+	fmt.Println(err)
+	_, err = xmath.NewAnchoredStepper(2, 10, 0.5, math.Inf(+1), -4.75, 0.25)
+	fmt.Println(err)
+	_, err = xmath.NewAnchoredStepper(2, 10, 0.5, math.Inf(+1), -4.5, 0.25)
+	fmt.Println(err)
+	_, err = xmath.NewAnchoredStepper(2, 10, 0.5, math.Inf(+1), math.Inf(-1), 0.255)
+	fmt.Println(err)
+	_, err = xmath.NewAnchoredStepper(2, 10, 0.5, 3.1, 1.1, 0.26)
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// <nil>
+	// anchor 0.25: anchor overflow
+	// anchor 0.255: anchor overflow
+	// <nil>
+}
+
+func ExampleStepper_Step_anchor() {
+	s, err := xmath.NewAnchoredStepper(2, 10, 0.5, math.Inf(+1), math.Inf(-1), 0.25)
+	if err != nil {
+		panic(err)
+	}
+	for i := -2; i <= +2; i++ {
+		fmt.Println(s.Step(i))
+	}
+	fmt.Println(s.Normalize(1.1))
+	fmt.Println(s.Index(1.1))
+	fmt.Println(s.Normalize(-0.5))
+	fmt.Println(s.Index(-0.5))
+
+	// Output:
+	// -0.75 <nil>
+	// -0.25 <nil>
+	// 0.25 <nil>
+	// 0.75 <nil>
+	// 1.25 <nil>
+	// 1.25 <nil>
+	// 2 <nil>
+	// -0.25 <nil>
+	// -1 <nil>
+}
+
+func ExampleStepperError() {
+	_, err := xmath.NewStepper(2, 10, 0.1, 3.015, 2.31)
+	fmt.Println(errors.Is(err, xmath.ErrStepperMaxOverflow))
+	var e *xmath.StepperError
+	if errors.As(err, &e) {
+		fmt.Println(e.Field, e.Value)
+	}
+
+	// Output:
+	// true
+	// max 3.015
+}
+
+func ExampleStepper_MarshalJSON() {
+	s, err := xmath.NewAnchoredStepper(2, 10, 0.1, math.Inf(+1), math.Inf(-1), 0.05)
+	if err != nil {
+		panic(err)
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(data))
+	var t xmath.Stepper
+	if err := json.Unmarshal(data, &t); err != nil {
+		panic(err)
+	}
+	fmt.Println(t.Step(1))
+	err = json.Unmarshal([]byte(`{"prec":2,"base":10,"step":"0.1","max":3.01,"min":2.315}`), &t)
+	fmt.Println(err)
+	err = json.Unmarshal([]byte(`{"prec":2,"base":40,"step":0.1,"max":3.01,"min":2.31}`), &t)
+	fmt.Println(err)
+	err = json.Unmarshal([]byte(`{"prec":2,"base":10,"step":0.1}`), &t)
+	fmt.Println(err)
+	err = json.Unmarshal([]byte(`{"prec":400,"base":10,"step":0.1,"max":3.01,"min":2.31}`), &t)
+	fmt.Println(err)
+	fmt.Println(t.Step(1))
+	err = json.Unmarshal([]byte(`{"prec":2,"base":10,"step":0.1,"max":3.01,"min":2.31,"anchor":null}`), &t)
+	fmt.Println(err)
+	fmt.Println(t.Step(1))
+
+	// Output:
+	// {"prec":2,"base":10,"step":0.1,"max":"+Inf","min":"-Inf","anchor":0.05}
+	// 0.15 <nil>
+	// min 2.315: min overflow
+	// base 40: invalid base
+	// invalid text
+	// prec 400: invalid text
+	// 0.15 <nil>
+	// <nil>
+	// 2.41 <nil>
+}
+
+func ExampleStepper_MarshalText() {
+	s, err := xmath.NewStepper(2, 10, 0.25, math.Inf(+1), -5)
+	if err != nil {
+		panic(err)
+	}
+	text, err := s.MarshalText()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(text))
+	var t xmath.Stepper
+	if err := t.UnmarshalText(text); err != nil {
+		panic(err)
+	}
+	fmt.Println(t.Step(2))
+	fmt.Println(t.UnmarshalText([]byte("prec=2 base=10 step=0.25 max=5")))
+	fmt.Println(t.UnmarshalText([]byte("prec=2 base=10 step=0.25 max=5 min=-5 unknown=1")))
+	fmt.Println(t.UnmarshalText([]byte("prec=-400 base=10 step=0.25 max=5 min=-5")))
+	fmt.Println(t.UnmarshalText([]byte("prec=400 base=10 step=0.25 max=5 min=-5")))
+
+	// Output:
+	// prec=2 base=10 step=0.25 max=+Inf min=-5 anchor=0
+	// -4.5 <nil>
+	// invalid text
+	// invalid text
+	// prec -400: invalid text
+	// prec 400: invalid text
+}
+
+func ExampleStepper_Rand() {
+	s, err := xmath.NewStepper(2, 10, 0.25, 5.1, -4.9)
+	if err != nil {
+		panic(err)
+	}
+	r := rand.New(rand.NewSource(1))
+	valid := true
+	for i := 0; i < 1000; i++ {
+		f, err := s.Rand(r)
+		if err != nil || !s.Contains(f) {
+			valid = false
+		}
+		f, err = s.Rand(nil)
+		if err != nil || !s.Contains(f) {
+			valid = false
+		}
+	}
+	fmt.Println(valid)
+	s, err = xmath.NewStepper(2, 10, 0.25, math.Inf(+1), -4.9)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s.Rand(nil))
+
+	// Output:
+	// true
+	// 0 range overflow
+}
+
+func ExampleStepper_Distance() {
+	s, err := xmath.NewStepper(2, 10, 0.05, 10, 0)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s.Distance(1.1, 1.3))
+	fmt.Println(s.Distance(1.3, 1.1))
+	fmt.Println(s.Distance(0.1, 0.7))
+	fmt.Println(s.Distance(1.12, 1.33))
+	fmt.Println(s.Distance(1, 11))
+	s, err = xmath.NewStepper(0, 10, 1, math.Inf(+1), math.Inf(-1))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s.Distance(-6e18, 6e18))
+
+	// Output:
+	// 4 <nil>
+	// -4 <nil>
+	// 12 <nil>
+	// 5 <nil>
+	// 0 max exceeded
+	// 0 range overflow
+}
+
+func ExampleStepper_Offset() {
+	s, err := xmath.NewStepper(2, 10, 0.05, 10, 0)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s.Offset(0.1, 4))
+	fmt.Println(s.Offset(0.7, -12))
+	fmt.Println(s.Offset(9.9, 3))
+	fmt.Println(s.Offset(0.1, -3))
+	fmt.Println(s.Offset(11, -1))
+	s, err = xmath.NewStepper(0, 10, 1, math.Inf(+1), math.Inf(-1))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s.Offset(9e18, 9e18))
+
+	// Output:
+	// 0.3 <nil>
+	// 0.1 <nil>
+	// 10 max exceeded
+	// 0 min exceeded
+	// 10 max exceeded
+	// 1.8e+19 <nil>
+}
+
+func ExampleStepper_Next() {
+	s, err := xmath.NewStepper(2, 10, 0.05, 10, 0)
+	if err != nil {
+		panic(err)
+	}
+	for _, f := range []float64{0.1, 0.12, 0.13, -1, 9.95, 10, math.NaN()} {
+		g, err := s.Next(f)
+		fmt.Print(g, " ", err, ", ")
+		fmt.Println(s.Prev(f))
+	}
+
+	// Output:
+	// 0.15 <nil>, 0.05 <nil>
+	// 0.15 <nil>, 0.1 <nil>
+	// 0.15 <nil>, 0.1 <nil>
+	// 0 <nil>, 0 min exceeded
+	// 10 <nil>, 9.9 <nil>
+	// 10 max exceeded, 9.95 <nil>
+	// NaN NaN value, NaN NaN value
+}
+
+func ExampleStepper_IndexBig() {
+	_, err := xmath.NewStepper(12, 10, 1e-12, 1e8, 0)
+	fmt.Println(err)
+	s, err := xmath.NewStepper(12, 10, 1e-12, 1e3, 0)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s.CountBig())
+	s, err = xmath.NewStepper(0, 10, 3, math.Inf(+1), 0)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s.CountBig())
+	fmt.Println(s.Index(3e20))
+	fmt.Println(s.IndexBig(3e20))
+	fmt.Println(s.IndexBig(math.Inf(+1)))
+	fmt.Println(s.IndexBig(-2))
+	fmt.Println(s.StepBig(new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil)))
+	fmt.Println(s.StepBig(new(big.Int).Add(new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil), big.NewInt(1))))
+	fmt.Println(s.StepBig(big.NewInt(-1)))
+	fmt.Println(s.Offset(3e20, -9e18))
+
+	// Output:
+	// max 1e+08: max overflow
+	// 1000000000000001
+	// <nil>
+	// 9223372036854775807 max exceeded
+	// 100000000000000000000 <nil>
+	// 9223372036854775807 max exceeded
+	// 0 min exceeded
+	// 3e+20 <nil>
+	// 3e+20 inexact result
+	// 0 min exceeded
+	// 2.73e+20 <nil>
+}
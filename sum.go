@@ -0,0 +1,145 @@
+package xmath
+
+import (
+	"math"
+	"math/big"
+)
+
+// KahanSum returns the sum of x... by Kahan's compensated summation in the floating point type of x.
+// Once the running sum is infinity or NaN, the rest of x is summed without compensation like NeumaierSum.
+func KahanSum[T Float](x ...T) T {
+	var sum, c T
+	for _, y := range x {
+		if math.IsInf(float64(sum), 0) || math.IsNaN(float64(sum)) {
+			sum += y
+			continue
+		}
+		y -= c
+		t := sum + y
+		c = (t - sum) - y
+		sum = t
+	}
+	return sum
+}
+
+// NeumaierSum returns the sum of x... by Neumaier's improved Kahan summation in the floating point type of x.
+// Unlike KahanSum, it compensates the error also when the next value is larger than the running sum.
+func NeumaierSum[T Float](x ...T) T {
+	var sum, c T
+	for _, y := range x {
+		t := sum + y
+		if math.Abs(float64(sum)) >= math.Abs(float64(y)) {
+			c += (sum - t) + y
+		} else {
+			c += (y - t) + sum
+		}
+		sum = t
+	}
+	if math.IsInf(float64(sum), 0) || math.IsNaN(float64(sum)) {
+		return sum
+	}
+	return sum + c
+}
+
+// neumaierSum returns the sum of float64 values of x multiplied by scale and its compensation by Neumaier's summation.
+func neumaierSum[T Number](x []T, scale float64) (sum, c float64) {
+	for _, a := range x {
+		y := float64(a) * scale
+		t := sum + y
+		if math.Abs(sum) >= math.Abs(y) {
+			c += (sum - t) + y
+		} else {
+			c += (y - t) + sum
+		}
+		sum = t
+	}
+	return
+}
+
+// PairwiseSum returns the sum of x... by pairwise summation in the floating point type of x.
+func PairwiseSum[T Float](x ...T) T {
+	if len(x) <= 8 {
+		var sum T
+		for _, y := range x {
+			sum += y
+		}
+		return sum
+	}
+	m := len(x) / 2
+	return PairwiseSum(x[:m]...) + PairwiseSum(x[m:]...)
+}
+
+// ExactSum returns the sum of x... which is exactly rounded to the floating point type of x.
+//
+// Special cases are:
+//	ExactSum(x, NaN) = NaN
+//	ExactSum(+Inf, -Inf) = NaN
+//	ExactSum(x, ±Inf) = ±Inf
+func ExactSum[T Float](x ...T) T {
+	total, special := exactSum(x)
+	if special != 0 || math.IsNaN(special) {
+		return T(special)
+	}
+	if isFloat32[T]() {
+		f, _ := total.Float32()
+		return T(f)
+	}
+	f, _ := total.Float64()
+	return T(f)
+}
+
+// exactSumPrec is enough precision to add float64 values exactly.
+const exactSumPrec = 2240
+
+// exactSum returns the exact sum of finite values of x as big.Float, and the sum of infinities and NaNs as special.
+// It uses Shewchuk's algorithm by float64 partials, and restarts with big.Float accumulator if any partial overflows.
+func exactSum[T Number](x []T) (total *big.Float, special float64) {
+	total = new(big.Float).SetPrec(exactSumPrec)
+	partials := make([]float64, 0, 8)
+	overflow := false
+	for _, a := range x {
+		f := float64(a)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			special += f
+			continue
+		}
+		if overflow {
+			continue
+		}
+		i := 0
+		for _, y := range partials {
+			if math.Abs(f) < math.Abs(y) {
+				f, y = y, f
+			}
+			hi := f + y
+			if math.IsInf(hi, 0) {
+				overflow = true
+				break
+			}
+			lo := y - (hi - f)
+			if lo != 0 {
+				partials[i] = lo
+				i++
+			}
+			f = hi
+		}
+		if !overflow {
+			partials = append(partials[:i], f)
+		}
+	}
+	if special != 0 || math.IsNaN(special) {
+		return total, special
+	}
+	if overflow {
+		t := new(big.Float)
+		for _, a := range x {
+			total.Add(total, t.SetFloat64(float64(a)))
+		}
+		return total, 0
+	}
+	t := new(big.Float)
+	for _, y := range partials {
+		total.Add(total, t.SetFloat64(y))
+	}
+	return total, 0
+}
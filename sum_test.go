@@ -0,0 +1,61 @@
+package xmath_test
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/goinsane/xmath"
+)
+
+func ExampleKahanSum() {
+	x := []float64{0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1}
+	fmt.Println(xmath.Sum(x...), xmath.KahanSum(x...), xmath.NeumaierSum(x...), xmath.PairwiseSum(x...), xmath.ExactSum(x...))
+	x = []float64{1e100, 1, -1e100}
+	fmt.Println(xmath.Sum(x...), xmath.KahanSum(x...), xmath.NeumaierSum(x...), xmath.PairwiseSum(x...), xmath.ExactSum(x...))
+	y := []float32{1e30, 1, -1e30}
+	fmt.Println(xmath.KahanSum(y...), xmath.NeumaierSum(y...), xmath.PairwiseSum(y...), xmath.ExactSum(y...))
+	x = []float64{math.Inf(+1), 1}
+	fmt.Println(xmath.Sum(x...), xmath.KahanSum(x...), xmath.NeumaierSum(x...), xmath.PairwiseSum(x...), xmath.ExactSum(x...))
+	x = []float64{1e308, 1e308, 1}
+	fmt.Println(xmath.Sum(x...), xmath.KahanSum(x...), xmath.NeumaierSum(x...), xmath.PairwiseSum(x...), xmath.ExactSum(x...))
+	x = []float64{1, -1e308, -1e308, math.Inf(+1)}
+	fmt.Println(xmath.Sum(x...), xmath.KahanSum(x...), xmath.NeumaierSum(x...), xmath.PairwiseSum(x...), xmath.ExactSum(x...))
+
+	// Output:
+	// 0.9999999999999999 1 1 1 1
+	// 0 0 1 0 1
+	// 0 1 0 1
+	// +Inf +Inf +Inf +Inf +Inf
+	// +Inf +Inf +Inf +Inf +Inf
+	// NaN NaN NaN NaN +Inf
+}
+
+func ExampleExactSum() {
+	fmt.Println(xmath.ExactSum(1e100, 1, 1e-100, -1e100))
+	fmt.Println(xmath.ExactSum(math.MaxFloat64, math.MaxFloat64, -math.MaxFloat64))
+	fmt.Println(xmath.ExactSum(math.MaxFloat64, math.MaxFloat64))
+	fmt.Println(xmath.ExactSum(1, math.Inf(+1)), xmath.ExactSum(math.Inf(-1), math.Inf(+1)), xmath.ExactSum(1, math.NaN()))
+	fmt.Println(xmath.ExactSum[float32](16777216, 1, 1), xmath.ExactSum[float32](16777216, 1))
+	fmt.Println(xmath.ExactSum[float64]())
+
+	// Output:
+	// 1
+	// 1.7976931348623157e+308
+	// +Inf
+	// +Inf NaN NaN
+	// 1.6777218e+07 1.6777216e+07
+	// 0
+}
+
+func ExampleAvg_stable() {
+	fmt.Println(xmath.Avg(1e100, 1, -1e100))
+	fmt.Println(xmath.Avg(math.MaxFloat64, math.MaxFloat64))
+	fmt.Println(xmath.Avg(0.1, 0.2, 0.3))
+	fmt.Println(xmath.Avg(1, math.Inf(-1)), xmath.Avg())
+
+	// Output:
+	// 0.3333333333333333
+	// 1.7976931348623157e+308
+	// 0.2
+	// -Inf 0
+}
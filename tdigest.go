@@ -0,0 +1,356 @@
+package xmath
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+)
+
+var (
+	ErrTDigestInvalidCompression = errors.New("invalid compression")
+	ErrTDigestInvalidData        = errors.New("invalid t-digest data")
+)
+
+// TDigest is a mergeable sketch to estimate quantiles of a stream of weighted values with bounded memory.
+// It's an implementation of merging t-digest of Dunning with the arcsine scale function.
+// The compression determines the accuracy and the memory: TDigest holds at most about compression centroids,
+// and the rank error of Quantile and CDF is expected to be less than 1/compression for the values without heavy duplicates.
+// The error is much smaller near the tails, so it's suitable for the percentiles such as p99 and p999.
+// TDigest isn't safe for concurrent use even for reading, because all of methods may merge buffered values.
+type TDigest struct {
+	compression float64
+	centroids   []tdigestCentroid
+	buffer      []tdigestCentroid
+	count       int64
+	weight      float64
+	min         float64
+	max         float64
+}
+
+type tdigestCentroid struct {
+	mean   float64
+	weight float64
+}
+
+// NewTDigest returns a new empty TDigest with given compression.
+// The compression must be finite and greater than or equal to 1. Typical values are between 50 and 1000.
+func NewTDigest(compression float64) (*TDigest, error) {
+	if !(compression >= 1) || math.IsInf(compression, 0) {
+		return nil, ErrTDigestInvalidCompression
+	}
+	return &TDigest{
+		compression: compression,
+		min:         math.Inf(+1),
+		max:         math.Inf(-1),
+	}, nil
+}
+
+// Compression returns the compression of TDigest.
+func (t *TDigest) Compression() float64 {
+	return t.compression
+}
+
+// Add is synonym with AddWeighted(x, 1).
+func (t *TDigest) Add(x float64) {
+	t.AddWeighted(x, 1)
+}
+
+// AddWeighted adds x with weight w.
+// NaN and infinite values, and the values with weight which is not greater than 0 are ignored.
+func (t *TDigest) AddWeighted(x float64, w float64) {
+	if math.IsNaN(x) || math.IsInf(x, 0) || !(w > 0) || math.IsInf(w, 0) {
+		return
+	}
+	t.buffer = append(t.buffer, tdigestCentroid{mean: x, weight: w})
+	t.count++
+	t.weight += w
+	t.min = math.Min(t.min, x)
+	t.max = math.Max(t.max, x)
+	if len(t.buffer) >= t.bufferSize() {
+		t.compress()
+	}
+}
+
+// Merge adds the values of x into TDigest. The compression of TDigest doesn't change.
+func (t *TDigest) Merge(x *TDigest) {
+	if x.count <= 0 {
+		return
+	}
+	x.compress()
+	centroids := make([]tdigestCentroid, len(x.centroids))
+	copy(centroids, x.centroids)
+	t.buffer = append(t.buffer, centroids...)
+	t.count += x.count
+	t.weight += x.weight
+	t.min = math.Min(t.min, x.min)
+	t.max = math.Max(t.max, x.max)
+	t.compress()
+}
+
+// Reset resets TDigest to empty.
+func (t *TDigest) Reset() {
+	*t = TDigest{
+		compression: t.compression,
+		min:         math.Inf(+1),
+		max:         math.Inf(-1),
+	}
+}
+
+// Count returns number of added values.
+func (t *TDigest) Count() int64 {
+	return t.count
+}
+
+// Weight returns total weight of added values.
+func (t *TDigest) Weight() float64 {
+	return t.weight
+}
+
+// Min returns the smallest added value.
+// It returns +Inf if TDigest is empty.
+func (t *TDigest) Min() float64 {
+	return t.min
+}
+
+// Max returns the largest added value.
+// It returns -Inf if TDigest is empty.
+func (t *TDigest) Max() float64 {
+	return t.max
+}
+
+// Centroids returns number of centroids after merging buffered values.
+func (t *TDigest) Centroids() int {
+	t.compress()
+	return len(t.centroids)
+}
+
+// Quantile returns the estimated weighted q-quantile of added values.
+// The values between the centroids are interpolated linearly, and Quantile(0) and Quantile(1) are exactly Min and Max.
+//
+// Special cases are:
+//	Quantile(q) = NaN if q is out of [0, 1] or TDigest is empty
+func (t *TDigest) Quantile(q float64) float64 {
+	if !(0 <= q && q <= 1) || t.count <= 0 {
+		return math.NaN()
+	}
+	t.compress()
+	target := q * t.weight
+	ranks, values := t.knots()
+	i := sort.SearchFloat64s(ranks, target)
+	if i <= 0 {
+		return values[0]
+	}
+	if i >= len(ranks) {
+		return values[len(values)-1]
+	}
+	r0, r1 := ranks[i-1], ranks[i]
+	v0, v1 := values[i-1], values[i]
+	return v0 + (target-r0)/(r1-r0)*(v1-v0)
+}
+
+// CDF returns the estimated fraction of total weight of added values which are less than or equal to x.
+// It's the inverse of Quantile by the same interpolation.
+//
+// Special cases are:
+//	CDF(x) = NaN if x is NaN or TDigest is empty
+//	CDF(x) = 0 if x < Min
+//	CDF(x) = 1 if x >= Max
+func (t *TDigest) CDF(x float64) float64 {
+	if math.IsNaN(x) || t.count <= 0 {
+		return math.NaN()
+	}
+	if x < t.min {
+		return 0
+	}
+	if x >= t.max {
+		return 1
+	}
+	t.compress()
+	ranks, values := t.knots()
+	i := sort.Search(len(values), func(i int) bool {
+		return values[i] > x
+	})
+	r0, r1 := ranks[i-1], ranks[i]
+	v0, v1 := values[i-1], values[i]
+	return (r0 + (x-v0)/(v1-v0)*(r1-r0)) / t.weight
+}
+
+// knots returns the ranks and values of interpolation knots which are min, the centers of centroids and max.
+func (t *TDigest) knots() (ranks []float64, values []float64) {
+	ranks = make([]float64, 0, len(t.centroids)+2)
+	values = make([]float64, 0, len(t.centroids)+2)
+	ranks = append(ranks, 0)
+	values = append(values, t.min)
+	var sum float64
+	for _, c := range t.centroids {
+		ranks = append(ranks, sum+c.weight/2)
+		values = append(values, c.mean)
+		sum += c.weight
+	}
+	ranks = append(ranks, t.weight)
+	values = append(values, t.max)
+	return
+}
+
+func (t *TDigest) bufferSize() int {
+	return int(math.Ceil(5 * t.compression))
+}
+
+// scale is the arcsine scale function k1 of t-digest.
+func (t *TDigest) scale(q float64) float64 {
+	return t.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// scaleInverse is the inverse of scale.
+func (t *TDigest) scaleInverse(k float64) float64 {
+	if k >= t.compression/4 {
+		return 1
+	}
+	return (math.Sin(k*2*math.Pi/t.compression) + 1) / 2
+}
+
+// compress merges buffered values and centroids, so that no centroid spans more than 1 on the scale.
+func (t *TDigest) compress() {
+	if len(t.buffer) <= 0 {
+		return
+	}
+	all := append(t.buffer, t.centroids...)
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].mean < all[j].mean
+	})
+	var total float64
+	for _, c := range all {
+		total += c.weight
+	}
+	centroids := make([]tdigestCentroid, 0, len(t.centroids)+1)
+	cur := all[0]
+	var sum float64
+	limit := t.scaleInverse(t.scale(0)+1) * total
+	for _, c := range all[1:] {
+		if sum+cur.weight+c.weight <= limit {
+			cur.weight += c.weight
+			cur.mean += (c.mean - cur.mean) * c.weight / cur.weight
+			continue
+		}
+		sum += cur.weight
+		centroids = append(centroids, cur)
+		limit = t.scaleInverse(t.scale(sum/total)+1) * total
+		cur = c
+	}
+	t.centroids = append(centroids, cur)
+	t.buffer = t.buffer[:0]
+}
+
+// tdigestJSON is the JSON representation of TDigest.
+type tdigestJSON struct {
+	Compression float64        `json:"compression"`
+	Count       int64          `json:"count"`
+	Min         jsonFloat      `json:"min"`
+	Max         jsonFloat      `json:"max"`
+	Centroids   [][2]jsonFloat `json:"centroids"`
+}
+
+// MarshalJSON is implementation of json.Marshaler.
+// The centroids are encoded to the pairs of mean and weight after merging buffered values.
+func (t *TDigest) MarshalJSON() ([]byte, error) {
+	t.compress()
+	v := &tdigestJSON{
+		Compression: t.compression,
+		Count:       t.count,
+		Min:         jsonFloat(t.min),
+		Max:         jsonFloat(t.max),
+		Centroids:   make([][2]jsonFloat, 0, len(t.centroids)),
+	}
+	for _, c := range t.centroids {
+		v.Centroids = append(v.Centroids, [2]jsonFloat{jsonFloat(c.mean), jsonFloat(c.weight)})
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON is implementation of json.Unmarshaler.
+func (t *TDigest) UnmarshalJSON(data []byte) error {
+	var v tdigestJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	centroids := make([]tdigestCentroid, 0, len(v.Centroids))
+	for _, c := range v.Centroids {
+		centroids = append(centroids, tdigestCentroid{mean: float64(c[0]), weight: float64(c[1])})
+	}
+	return t.set(v.Compression, v.Count, float64(v.Min), float64(v.Max), centroids)
+}
+
+// tdigestBinaryVersion is the version of binary encoding of TDigest.
+const tdigestBinaryVersion = 1
+
+// MarshalBinary is implementation of encoding.BinaryMarshaler.
+// The data consists of a version byte, compression, count, min, max, number of centroids and the pairs of mean and weight in big endian.
+func (t *TDigest) MarshalBinary() (data []byte, err error) {
+	t.compress()
+	data = make([]byte, 1+5*8+16*len(t.centroids))
+	data[0] = tdigestBinaryVersion
+	binary.BigEndian.PutUint64(data[1:], math.Float64bits(t.compression))
+	binary.BigEndian.PutUint64(data[9:], uint64(t.count))
+	binary.BigEndian.PutUint64(data[17:], math.Float64bits(t.min))
+	binary.BigEndian.PutUint64(data[25:], math.Float64bits(t.max))
+	binary.BigEndian.PutUint64(data[33:], uint64(len(t.centroids)))
+	for i, c := range t.centroids {
+		binary.BigEndian.PutUint64(data[41+16*i:], math.Float64bits(c.mean))
+		binary.BigEndian.PutUint64(data[49+16*i:], math.Float64bits(c.weight))
+	}
+	return data, nil
+}
+
+// UnmarshalBinary is implementation of encoding.BinaryUnmarshaler.
+func (t *TDigest) UnmarshalBinary(data []byte) error {
+	if len(data) < 1+5*8 || data[0] != tdigestBinaryVersion {
+		return ErrTDigestInvalidData
+	}
+	n := binary.BigEndian.Uint64(data[33:])
+	if n != uint64(len(data)-41)/16 || (len(data)-41)%16 != 0 {
+		return ErrTDigestInvalidData
+	}
+	centroids := make([]tdigestCentroid, n)
+	for i := range centroids {
+		centroids[i].mean = math.Float64frombits(binary.BigEndian.Uint64(data[41+16*i:]))
+		centroids[i].weight = math.Float64frombits(binary.BigEndian.Uint64(data[49+16*i:]))
+	}
+	return t.set(math.Float64frombits(binary.BigEndian.Uint64(data[1:])), int64(binary.BigEndian.Uint64(data[9:])),
+		math.Float64frombits(binary.BigEndian.Uint64(data[17:])), math.Float64frombits(binary.BigEndian.Uint64(data[25:])), centroids)
+}
+
+// set validates and sets the internal state by given values.
+func (t *TDigest) set(compression float64, count int64, min, max float64, centroids []tdigestCentroid) error {
+	if !(compression >= 1) || math.IsInf(compression, 0) {
+		return ErrTDigestInvalidCompression
+	}
+	if count < 0 || (count == 0) != (len(centroids) == 0) || int64(len(centroids)) > count {
+		return ErrTDigestInvalidData
+	}
+	var weight float64
+	for i, c := range centroids {
+		if !(c.weight > 0) || math.IsInf(c.weight, 0) || !(min <= c.mean && c.mean <= max) {
+			return ErrTDigestInvalidData
+		}
+		if i > 0 && c.mean < centroids[i-1].mean {
+			return ErrTDigestInvalidData
+		}
+		weight += c.weight
+	}
+	if count == 0 {
+		min, max = math.Inf(+1), math.Inf(-1)
+	} else if math.IsInf(weight, 0) {
+		return ErrTDigestInvalidData
+	}
+	*t = TDigest{
+		compression: compression,
+		centroids:   centroids,
+		count:       count,
+		weight:      weight,
+		min:         min,
+		max:         max,
+	}
+	return nil
+}
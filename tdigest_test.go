@@ -0,0 +1,112 @@
+package xmath_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/goinsane/xmath"
+)
+
+func ExampleTDigest() {
+	t, err := xmath.NewTDigest(100)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(t.Quantile(0.5), t.CDF(1), t.Min(), t.Max())
+	for _, f := range []float64{5, 1, 4, 2, 3, math.NaN(), math.Inf(+1)} {
+		t.Add(f)
+	}
+	t.AddWeighted(100, 0)
+	fmt.Println(t.Count(), t.Weight(), t.Min(), t.Max(), t.Centroids())
+	fmt.Println(t.Quantile(0), t.Quantile(0.5), t.Quantile(0.7), t.Quantile(1), t.Quantile(1.5))
+	fmt.Println(t.CDF(0), t.CDF(3), t.CDF(3.5), t.CDF(5), t.CDF(math.NaN()))
+	_, err = xmath.NewTDigest(0.5)
+	fmt.Println(err)
+
+	// Output:
+	// NaN NaN +Inf -Inf
+	// 5 5 1 5 5
+	// 1 3 4 5 NaN
+	// 0 0.5 0.6 1 NaN
+	// invalid compression
+}
+
+func ExampleTDigest_errorBound() {
+	const compression = 100
+	r := rand.New(rand.NewSource(1))
+	var parts [4]*xmath.TDigest
+	for i := range parts {
+		parts[i], _ = xmath.NewTDigest(compression)
+	}
+	x := make([]float64, 100000)
+	for i := range x {
+		x[i] = r.NormFloat64()
+		parts[i%len(parts)].Add(x[i])
+	}
+	t, _ := xmath.NewTDigest(compression)
+	for _, p := range parts {
+		t.Merge(p)
+	}
+	sort.Float64s(x)
+	rank := func(f float64) float64 {
+		return float64(sort.SearchFloat64s(x, f)) / float64(len(x))
+	}
+	fmt.Println(t.Count(), t.Centroids() <= compression)
+	for _, q := range []float64{0.001, 0.01, 0.25, 0.5, 0.75, 0.99, 0.999} {
+		fmt.Println(q,
+			math.Abs(rank(t.Quantile(q))-q) <= 1.0/compression,
+			math.Abs(t.CDF(xmath.Quantile(q, xmath.QuantileR1, x...))-q) <= 1.0/compression)
+	}
+
+	// Output:
+	// 100000 true
+	// 0.001 true true
+	// 0.01 true true
+	// 0.25 true true
+	// 0.5 true true
+	// 0.75 true true
+	// 0.99 true true
+	// 0.999 true true
+}
+
+func ExampleTDigest_MarshalJSON() {
+	t, _ := xmath.NewTDigest(10)
+	t.Add(1)
+	t.AddWeighted(2, 3)
+	data, err := json.Marshal(t)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(string(data))
+	var u xmath.TDigest
+	fmt.Println(json.Unmarshal(data, &u), u.Count(), u.Weight(), u.Quantile(0.5))
+	fmt.Println(json.Unmarshal([]byte(`{"compression":10,"count":1,"min":1,"max":1,"centroids":[[2,1]]}`), &u))
+	fmt.Println(json.Unmarshal([]byte(`{"compression":0}`), &u))
+
+	// Output:
+	// {"compression":10,"count":2,"min":1,"max":2,"centroids":[[1,1],[2,3]]}
+	// <nil> 2 4 1.75
+	// invalid t-digest data
+	// invalid compression
+}
+
+func ExampleTDigest_MarshalBinary() {
+	t, _ := xmath.NewTDigest(50)
+	for i := 0; i < 1000; i++ {
+		t.Add(float64(i))
+	}
+	data, err := t.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	var u xmath.TDigest
+	fmt.Println(u.UnmarshalBinary(data), u.Count(), u.Centroids() == t.Centroids(), u.Quantile(0.9) == t.Quantile(0.9))
+	fmt.Println(u.UnmarshalBinary(data[:len(data)-1]))
+
+	// Output:
+	// <nil> 1000 true true
+	// invalid t-digest data
+}
@@ -0,0 +1,136 @@
+package xmath
+
+import (
+	"math"
+	"sort"
+)
+
+// TieredStepper is a utility to step and normalize floating point values by the step of the band which the value is in.
+// It's composed of contiguous Scales such as tick tables, and indexes all bands globally.
+type TieredStepper struct {
+	prec     int
+	base     int
+	segments []Scale
+	maxs     []float64
+	mins     []float64
+	offsets  []int64
+	count    int64
+}
+
+// NewTieredStepper returns a new TieredStepper with given segments in ascending order.
+// All of segments must have same precision and base, and must have finite range.
+// The last step of each segment must be equal to the first step of the next segment.
+func NewTieredStepper(segments ...Scale) (s *TieredStepper, err error) {
+	if len(segments) <= 0 {
+		return nil, ErrStepperRangeOverflow
+	}
+	s = &TieredStepper{
+		prec:     segments[0].Prec(),
+		base:     segments[0].Base(),
+		segments: make([]Scale, 0, len(segments)),
+		maxs:     make([]float64, 0, len(segments)),
+		mins:     make([]float64, 0, len(segments)),
+		offsets:  make([]int64, 0, len(segments)),
+	}
+	for i, seg := range segments {
+		if seg.Prec() != s.prec || seg.Base() != s.base {
+			return nil, ErrStepperIncompatible
+		}
+		count := seg.Count64()
+		if count <= 0 {
+			return nil, ErrStepperRangeOverflow
+		}
+		min, _ := seg.Step64(0)
+		max, _ := seg.Step64(count - 1)
+		if i > 0 {
+			if min != s.maxs[i-1] {
+				return nil, ErrStepperDiscontinuous
+			}
+			s.count--
+		}
+		if s.count > math.MaxInt64-count {
+			return nil, ErrStepperRangeOverflow
+		}
+		s.segments = append(s.segments, seg)
+		s.maxs = append(s.maxs, max)
+		s.mins = append(s.mins, min)
+		s.offsets = append(s.offsets, s.count)
+		s.count += count
+	}
+	return s, nil
+}
+
+// Prec returns precision of the TieredStepper.
+func (s *TieredStepper) Prec() int {
+	return s.prec
+}
+
+// Base returns base of the TieredStepper.
+func (s *TieredStepper) Base() int {
+	return s.base
+}
+
+// Count is same with Count64 if count is less than or equal to MaxIntValue.
+// If count is greater than MaxIntValue, it returns MaxIntValue.
+func (s *TieredStepper) Count() int {
+	if s.count > MaxIntValue {
+		return MaxIntValue
+	}
+	return int(s.count)
+}
+
+// Count64 returns number of step for all bands.
+// The edge steps between bands are counted once.
+func (s *TieredStepper) Count64() int64 {
+	return s.count
+}
+
+// Step is same with Step64 except that Step indexes up to MaxIntValue.
+func (s *TieredStepper) Step(index int) (float64, error) {
+	return s.Step64(int64(index))
+}
+
+// Step64 returns proper step value by given global index.
+func (s *TieredStepper) Step64(index int64) (float64, error) {
+	if index >= s.count {
+		return s.maxs[len(s.maxs)-1], ErrStepperMaxExceeded
+	}
+	if index < 0 {
+		return s.mins[0], ErrStepperMinExceeded
+	}
+	i := sort.Search(len(s.offsets), func(i int) bool {
+		return s.offsets[i] > index
+	}) - 1
+	return s.segments[i].Step64(index - s.offsets[i])
+}
+
+// Normalize returns normalized float value by the grid of the band which f is in.
+func (s *TieredStepper) Normalize(f float64) (float64, error) {
+	return s.segments[s.segmentIndex(f)].Normalize(f)
+}
+
+// Index returns global index of the step which f is normalized to.
+// If the normalized step is out of range, it returns the index of the nearest bound with an error.
+func (s *TieredStepper) Index(f float64) (int64, error) {
+	i := s.segmentIndex(f)
+	index, err := s.segments[i].Index(f)
+	return s.offsets[i] + index, err
+}
+
+// Contains checks f is exactly one of the steps of TieredStepper.
+func (s *TieredStepper) Contains(f float64) bool {
+	return containsStep(s, f)
+}
+
+func (s *TieredStepper) segmentIndex(f float64) int {
+	if math.IsNaN(f) {
+		return 0
+	}
+	i := sort.Search(len(s.segments), func(i int) bool {
+		return f < s.maxs[i]
+	})
+	if i >= len(s.segments) {
+		i = len(s.segments) - 1
+	}
+	return i
+}
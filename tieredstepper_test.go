@@ -0,0 +1,124 @@
+package xmath_test
+
+import (
+	"fmt"
+
+	"github.com/goinsane/xmath"
+)
+
+func newTickTable() *xmath.TieredStepper {
+	s1, err := xmath.NewStepper(2, 10, 0.01, 1, 0.01)
+	if err != nil {
+		panic(err)
+	}
+	s2, err := xmath.NewStepper(2, 10, 0.05, 10, 1)
+	if err != nil {
+		panic(err)
+	}
+	s3, err := xmath.NewStepper(2, 10, 0.5, 100, 10)
+	if err != nil {
+		panic(err)
+	}
+	s, err := xmath.NewTieredStepper(s1, s2, s3)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func ExampleNewTieredStepper() {
+	s1, _ := xmath.NewStepper(2, 10, 0.01, 1, 0.01)
+	s2, _ := xmath.NewStepper(2, 10, 0.05, 10, 1)
+	s3, _ := xmath.NewStepper(2, 10, 0.05, 10, 1.5)
+	s4, _ := xmath.NewStepper(3, 10, 0.05, 10, 1)
+	var err error
+	_, err = xmath.NewTieredStepper(s1, s2)
+	fmt.Println(err)
+	_, err = xmath.NewTieredStepper(s1, s3)
+	fmt.Println(err)
+	_, err = xmath.NewTieredStepper(s2, s1)
+	fmt.Println(err)
+	_, err = xmath.NewTieredStepper(s1, s4)
+	fmt.Println(err)
+
+	// Output:
+	// <nil>
+	// discontinuous steppers
+	// discontinuous steppers
+	// incompatible steppers
+}
+
+func ExampleTieredStepper_Step() {
+	s := newTickTable()
+	fmt.Println(s.Count())
+	for _, i := range []int{-1, 0, 98, 99, 100, 278, 279, 280, s.Count() - 1, s.Count()} {
+		fmt.Println(s.Step(i))
+	}
+
+	// Output:
+	// 460
+	// 0.01 min exceeded
+	// 0.01 <nil>
+	// 0.99 <nil>
+	// 1 <nil>
+	// 1.05 <nil>
+	// 9.95 <nil>
+	// 10 <nil>
+	// 10.5 <nil>
+	// 100 <nil>
+	// 100 max exceeded
+}
+
+func ExampleTieredStepper_Normalize() {
+	s := newTickTable()
+	for _, f := range []float64{0.001, 0.123, 0.996, 1.024, 1.026, 9.98, 10.2, 10.25, 99.9, 100.3} {
+		n, err := s.Normalize(f)
+		i, _ := s.Index(f)
+		fmt.Println(n, i, err)
+	}
+
+	// Output:
+	// 0.01 0 min exceeded
+	// 0.12 11 <nil>
+	// 1 99 <nil>
+	// 1 99 <nil>
+	// 1.05 100 <nil>
+	// 10 279 <nil>
+	// 10 279 <nil>
+	// 10.5 280 <nil>
+	// 100 459 <nil>
+	// 100 459 max exceeded
+}
+
+func ExampleNewTieredStepper_scale() {
+	s1, err := xmath.NewDecadeStepper(2, 10, []float64{1, 2, 5}, 1, 0.01)
+	if err != nil {
+		panic(err)
+	}
+	s2, err := xmath.NewStepper(2, 10, 0.25, 2, 1)
+	if err != nil {
+		panic(err)
+	}
+	s, err := xmath.NewTieredStepper(s1, s2)
+	if err != nil {
+		panic(err)
+	}
+	for i := 0; i < s.Count(); i++ {
+		fmt.Println(s.Step(i))
+	}
+	fmt.Println(s.Contains(0.5), s.Contains(0.75), s.Contains(1.75))
+
+	// Output:
+	// 0.01 <nil>
+	// 0.02 <nil>
+	// 0.05 <nil>
+	// 0.1 <nil>
+	// 0.2 <nil>
+	// 0.5 <nil>
+	// 1 <nil>
+	// 1.25 <nil>
+	// 1.5 <nil>
+	// 1.75 <nil>
+	// 2 <nil>
+	// true false true
+}
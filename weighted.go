@@ -0,0 +1,204 @@
+package xmath
+
+import (
+	"math"
+	"math/big"
+)
+
+// Trade is a pair of price and quantity for VWAP.
+type Trade struct {
+	Price    float64
+	Quantity float64
+}
+
+// TradeInt is a pair of integer price and quantity for VWAPInt2, such as the price in ticks and the quantity in lots.
+type TradeInt struct {
+	Price    int64
+	Quantity int64
+}
+
+// WeightedSum returns the sum of products of x and w.
+// The products are summed exactly, so the result is rounded once unless the products underflow.
+// It panics unless x and w have same length.
+//
+// Special cases are:
+//	WeightedSum(x, w) = NaN if any product is NaN, such as ±Inf*0
+//	WeightedSum(x, w) = NaN if the products contain both of +Inf and -Inf
+func WeightedSum(x, w []float64) float64 {
+	panicForLengthMismatch(len(x), len(w))
+	total, special := exactDot(x, w)
+	if special != 0 || math.IsNaN(special) {
+		return special
+	}
+	sum, _ := total.Float64()
+	return sum
+}
+
+// WeightedAvg returns the weighted arithmetic mean of x by weights w.
+// The sums of products and weights are computed exactly, so the result is rounded once unless the products underflow.
+// If the total weight is 0, the result is same with SafeDiv(WeightedSum(x, w), 0, allowNaN).
+// It panics unless x and w have same length.
+func WeightedAvg(x, w []float64, allowNaN bool) float64 {
+	panicForLengthMismatch(len(x), len(w))
+	num, numSpecial := exactDot(x, w)
+	den, denSpecial := exactSum(w)
+	if numSpecial != 0 || math.IsNaN(numSpecial) || denSpecial != 0 || math.IsNaN(denSpecial) || den.Sign() == 0 {
+		if numSpecial == 0 {
+			numSpecial, _ = num.Float64()
+		}
+		if denSpecial == 0 {
+			denSpecial, _ = den.Float64()
+		}
+		return SafeDiv(numSpecial, denSpecial, allowNaN)
+	}
+	avg, _ := new(big.Float).SetPrec(53).Quo(num, den).Float64()
+	return avg
+}
+
+// WeightedSumInt2 returns the sum of products of x and w.
+// If the result overflows, it returns overflow is true and the sum wrapped around like SumInt2.
+// It panics unless x and w have same length.
+func WeightedSumInt2(x, w []int64) (sum int64, overflow bool) {
+	panicForLengthMismatch(len(x), len(w))
+	for i, a := range x {
+		sum += a * w[i]
+	}
+	return sum, !intDot(x, w).IsInt64()
+}
+
+// WeightedAvgInt2 returns the weighted arithmetic mean of x by weights w, truncated toward zero like AvgInt2.
+// The sums of products and weights are computed exactly, so it returns overflow is true only if the mean overflows.
+// If the total weight is 0, it returns 0 if the sum of products is 0.
+// Otherwise, it returns MaxInt64 or MinInt64 by the sign of the sum of products with overflow is true, similar with SafeDiv.
+// It panics unless x and w have same length.
+func WeightedAvgInt2(x, w []int64) (avg int64, overflow bool) {
+	panicForLengthMismatch(len(x), len(w))
+	num := intDot(x, w)
+	den := new(big.Int)
+	t := new(big.Int)
+	for _, b := range w {
+		den.Add(den, t.SetInt64(b))
+	}
+	if den.Sign() == 0 {
+		switch num.Sign() {
+		case +1:
+			return math.MaxInt64, true
+		case -1:
+			return math.MinInt64, true
+		}
+		return 0, false
+	}
+	num.Quo(num, den)
+	if !num.IsInt64() {
+		if num.Sign() > 0 {
+			return math.MaxInt64, true
+		}
+		return math.MinInt64, true
+	}
+	return num.Int64(), false
+}
+
+// WeightedSumUint2 returns the sum of products of x and w.
+// If the result overflows, it returns overflow is true and the sum wrapped around like SumUint2.
+// It panics unless x and w have same length.
+func WeightedSumUint2(x, w []uint64) (sum uint64, overflow bool) {
+	panicForLengthMismatch(len(x), len(w))
+	for i, a := range x {
+		sum += a * w[i]
+	}
+	return sum, !uintDot(x, w).IsUint64()
+}
+
+// WeightedAvgUint2 returns the weighted arithmetic mean of x by weights w, truncated toward zero like AvgUint2.
+// The sums of products and weights are computed exactly, so the mean can't overflow.
+// If the total weight is 0, it returns 0.
+// It panics unless x and w have same length.
+func WeightedAvgUint2(x, w []uint64) (avg uint64, overflow bool) {
+	panicForLengthMismatch(len(x), len(w))
+	num := uintDot(x, w)
+	den := new(big.Int)
+	t := new(big.Int)
+	for _, b := range w {
+		den.Add(den, t.SetUint64(b))
+	}
+	if den.Sign() == 0 {
+		return 0, false
+	}
+	return num.Quo(num, den).Uint64(), false
+}
+
+// VWAP returns the volume weighted average price of trades.
+// It's same with WeightedAvg(prices, quantities, allowNaN).
+func VWAP(trades []Trade, allowNaN bool) float64 {
+	prices := make([]float64, len(trades))
+	quantities := make([]float64, len(trades))
+	for i, t := range trades {
+		prices[i], quantities[i] = t.Price, t.Quantity
+	}
+	return WeightedAvg(prices, quantities, allowNaN)
+}
+
+// VWAPInt2 returns the volume weighted average price of trades in integer.
+// It's same with WeightedAvgInt2(prices, quantities).
+func VWAPInt2(trades []TradeInt) (vwap int64, overflow bool) {
+	prices := make([]int64, len(trades))
+	quantities := make([]int64, len(trades))
+	for i, t := range trades {
+		prices[i], quantities[i] = t.Price, t.Quantity
+	}
+	return WeightedAvgInt2(prices, quantities)
+}
+
+// exactDot returns the exact sum of products of x and w like exactSum.
+// The products are split to the pairs of rounded product and its error by FMA, and then summed exactly.
+func exactDot(x, w []float64) (total *big.Float, special float64) {
+	terms := make([]float64, 0, 2*len(x))
+	for i, a := range x {
+		b := w[i]
+		p := a * b
+		if math.IsInf(p, 0) && !math.IsInf(a, 0) && !math.IsInf(b, 0) {
+			return bigDot(x, w)
+		}
+		terms = append(terms, p)
+		if e := math.FMA(a, b, -p); e != 0 && !math.IsNaN(e) && !math.IsInf(e, 0) {
+			terms = append(terms, e)
+		}
+	}
+	return exactSum(terms)
+}
+
+// bigDot is the slow path of exactDot for the products which overflow float64.
+func bigDot(x, w []float64) (total *big.Float, special float64) {
+	total = new(big.Float).SetPrec(2 * exactSumPrec)
+	t := new(big.Float).SetPrec(2 * 53)
+	u := new(big.Float)
+	for i, a := range x {
+		b := w[i]
+		if math.IsNaN(a) || math.IsInf(a, 0) || math.IsNaN(b) || math.IsInf(b, 0) {
+			special += a * b
+			continue
+		}
+		total.Add(total, t.Mul(t.SetFloat64(a), u.SetFloat64(b)))
+	}
+	return total, special
+}
+
+// intDot returns the exact sum of products of x and w.
+func intDot(x, w []int64) *big.Int {
+	total := new(big.Int)
+	t, u := new(big.Int), new(big.Int)
+	for i, a := range x {
+		total.Add(total, t.Mul(t.SetInt64(a), u.SetInt64(w[i])))
+	}
+	return total
+}
+
+// uintDot returns the exact sum of products of x and w.
+func uintDot(x, w []uint64) *big.Int {
+	total := new(big.Int)
+	t, u := new(big.Int), new(big.Int)
+	for i, a := range x {
+		total.Add(total, t.Mul(t.SetUint64(a), u.SetUint64(w[i])))
+	}
+	return total
+}
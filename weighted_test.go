@@ -0,0 +1,94 @@
+package xmath_test
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/goinsane/xmath"
+)
+
+func ExampleWeightedSum() {
+	fmt.Println(xmath.WeightedSum([]float64{1, 2, 3}, []float64{0.5, 0.25, 0.25}))
+	fmt.Println(xmath.WeightedSum([]float64{0.1, 0.1, 0.1}, []float64{1, 1, -1}))
+	fmt.Println(xmath.WeightedSum([]float64{1e300, -1e300, 1}, []float64{1e10, 1e10, 1}))
+	fmt.Println(xmath.WeightedSum([]float64{1 + 0x1p-30, -1}, []float64{1 - 0x1p-30, 1}))
+	fmt.Println(xmath.WeightedSum([]float64{math.Inf(+1), 1}, []float64{0, 1}), xmath.WeightedSum(nil, nil))
+
+	// Output:
+	// 1.75
+	// 0.1
+	// 1
+	// -8.673617379884035e-19
+	// NaN 0
+}
+
+func ExampleWeightedAvg() {
+	fmt.Println(xmath.WeightedAvg([]float64{1, 2, 3}, []float64{1, 1, 2}, true))
+	fmt.Println(xmath.WeightedAvg([]float64{1e308, 1e308}, []float64{10, 10}, true))
+	fmt.Println(xmath.WeightedAvg([]float64{1, 2}, []float64{0, 0}, true), xmath.WeightedAvg([]float64{1, 2}, []float64{0, 0}, false))
+	fmt.Println(xmath.WeightedAvg([]float64{1, 2}, []float64{1, -1}, true), xmath.WeightedAvg(nil, nil, false))
+	fmt.Println(xmath.WeightedAvg([]float64{1, math.Inf(+1)}, []float64{1, 1}, true))
+
+	// Output:
+	// 2.25
+	// 1e+308
+	// NaN 0
+	// -Inf 0
+	// +Inf
+}
+
+func ExampleWeightedSumInt2() {
+	fmt.Println(xmath.WeightedSumInt2([]int64{1, 2, 3}, []int64{4, 5, 6}))
+	fmt.Println(xmath.WeightedSumInt2([]int64{math.MaxInt64, -1}, []int64{2, math.MaxInt64}))
+	fmt.Println(xmath.WeightedSumInt2([]int64{math.MaxInt64}, []int64{2}))
+	fmt.Println(xmath.WeightedSumUint2([]uint64{1, 2, 3}, []uint64{4, 5, 6}))
+	fmt.Println(xmath.WeightedSumUint2([]uint64{math.MaxUint64}, []uint64{2}))
+
+	// Output:
+	// 32 false
+	// 9223372036854775807 false
+	// -2 true
+	// 32 false
+	// 18446744073709551614 true
+}
+
+func ExampleWeightedAvgInt2() {
+	fmt.Println(xmath.WeightedAvgInt2([]int64{10, 20, 40}, []int64{1, 1, 2}))
+	fmt.Println(xmath.WeightedAvgInt2([]int64{-7, 0}, []int64{1, 1}))
+	fmt.Println(xmath.WeightedAvgInt2([]int64{math.MaxInt64, math.MaxInt64}, []int64{math.MaxInt64, math.MaxInt64}))
+	fmt.Println(xmath.WeightedAvgInt2([]int64{math.MaxInt64, 0}, []int64{2, -1}))
+	fmt.Println(xmath.WeightedAvgInt2([]int64{1, 2}, []int64{1, -1}))
+	fmt.Println(xmath.WeightedAvgInt2(nil, nil))
+	fmt.Println(xmath.WeightedAvgUint2([]uint64{math.MaxUint64, math.MaxUint64 - 2}, []uint64{math.MaxUint64, math.MaxUint64}))
+	fmt.Println(xmath.WeightedAvgUint2([]uint64{1}, []uint64{0}))
+
+	// Output:
+	// 27 false
+	// -3 false
+	// 9223372036854775807 false
+	// 9223372036854775807 true
+	// -9223372036854775808 true
+	// 0 false
+	// 18446744073709551614 false
+	// 0 false
+}
+
+func ExampleVWAP() {
+	trades := []xmath.Trade{
+		{Price: 100.5, Quantity: 200},
+		{Price: 101, Quantity: 100},
+		{Price: 99.75, Quantity: 100},
+	}
+	fmt.Println(xmath.VWAP(trades, true))
+	fmt.Println(xmath.VWAP(nil, true), xmath.VWAP(nil, false))
+	fmt.Println(xmath.VWAPInt2([]xmath.TradeInt{
+		{Price: 10050, Quantity: 2},
+		{Price: 10100, Quantity: 1},
+		{Price: 9975, Quantity: 1},
+	}))
+
+	// Output:
+	// 100.4375
+	// NaN 0
+	// 10043 false
+}
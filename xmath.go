@@ -5,6 +5,7 @@ import (
 	crand "crypto/rand"
 	"math"
 	"math/big"
+	"strconv"
 )
 
 const (
@@ -32,50 +33,365 @@ const (
 )
 
 // FloorP returns the greatest value less than or equal to x with specified decimal precision.
+// It's same with FloorPB(x, prec, 10).
 func FloorP(x float64, prec int) float64 {
-	k := math.Pow10(prec)
-	return math.Floor(x*k) / k
+	return roundPM(x, prec, 10, RoundFloor, 64)
 }
 
 // FloorPB returns the greatest value less than or equal to x with specified precision of base.
+// It's same with RoundPM(x, prec, base, RoundFloor).
 // It panics unless base is in valid range.
 func FloorPB(x float64, prec int, base int) float64 {
 	panicForInvalidBase(base)
-	k := math.Pow(float64(base), float64(prec))
-	return math.Floor(x*k) / k
+	return roundPM(x, prec, base, RoundFloor, 64)
 }
 
 // CeilP returns the least value greater than or equal to x with specified decimal precision.
+// It's same with CeilPB(x, prec, 10).
 func CeilP(x float64, prec int) float64 {
-	k := math.Pow10(prec)
-	return math.Ceil(x*k) / k
+	return roundPM(x, prec, 10, RoundCeil, 64)
 }
 
 // CeilPB returns the least value greater than or equal to x with specified precision of base.
+// It's same with RoundPM(x, prec, base, RoundCeil).
 // It panics unless base is in valid range.
 func CeilPB(x float64, prec int, base int) float64 {
 	panicForInvalidBase(base)
-	k := math.Pow(float64(base), float64(prec))
-	return math.Ceil(x*k) / k
+	return roundPM(x, prec, base, RoundCeil, 64)
 }
 
 // Round returns the nearest integer value, rounding half away from zero.
+// It's same with RoundP(x, 0).
 func Round(x float64) float64 {
-	return math.Floor(x + 0.5)
+	return math.Round(x)
 }
 
 // RoundP returns the nearest integer value, rounding half away from zero with specified decimal precision.
+// It's same with RoundPB(x, prec, 10).
 func RoundP(x float64, prec int) float64 {
-	k := math.Pow10(prec)
-	return math.Floor(x*k+0.5) / k
+	return roundPM(x, prec, 10, RoundHalfAwayFromZero, 64)
 }
 
 // RoundPB returns the nearest integer value, rounding half away from zero with specified precision of base.
+// It's same with RoundPM(x, prec, base, RoundHalfAwayFromZero).
 // It panics unless base is in valid range.
 func RoundPB(x float64, prec int, base int) float64 {
 	panicForInvalidBase(base)
-	k := math.Pow(float64(base), float64(prec))
-	return math.Floor(x*k+0.5) / k
+	return roundPM(x, prec, base, RoundHalfAwayFromZero, 64)
+}
+
+// StochasticRoundPB rounds x up or down to the grid with specified precision of base randomly.
+// It rounds up with probability equal to the fractional part of x on the grid, so the expected value of the result is x.
+// The random numbers in [0, 1) are drawn from rnd. If rnd is nil, it uses CryptoRandFloat.
+// It panics unless base is in valid range.
+func StochasticRoundPB(x float64, prec int, base int, rnd func() float64) float64 {
+	panicForInvalidBase(base)
+	if rnd == nil {
+		rnd = CryptoRandFloat
+	}
+	return stochasticRoundPB(x, prec, base, rnd)
+}
+
+// StochasticRoundPBSlice rounds each value of src into dst like StochasticRoundPB.
+// It panics if dst is shorter than src, or base isn't in valid range.
+func StochasticRoundPBSlice(dst, src []float64, prec int, base int, rnd func() float64) {
+	panicForInvalidBase(base)
+	dst = dst[:len(src)]
+	if rnd == nil {
+		rnd = CryptoRandFloat
+	}
+	for i, x := range src {
+		dst[i] = stochasticRoundPB(x, prec, base, rnd)
+	}
+}
+
+func stochasticRoundPB(x float64, prec int, base int, rnd func() float64) float64 {
+	lo, hi := roundPM(x, prec, base, RoundFloor, 64), roundPM(x, prec, base, RoundCeil, 64)
+	switch {
+	case lo == hi:
+		return lo
+	case math.IsInf(lo, -1):
+		return hi
+	case math.IsInf(hi, +1):
+		return lo
+	}
+	if rnd() < (x-lo)/(hi-lo) {
+		return hi
+	}
+	return lo
+}
+
+// RoundMode determines the rounding of RoundPM and RoundPM32.
+type RoundMode int
+
+const (
+	// RoundHalfAwayFromZero rounds to the nearest, and ties away from zero.
+	RoundHalfAwayFromZero RoundMode = iota
+	// RoundHalfTowardZero rounds to the nearest, and ties toward zero.
+	RoundHalfTowardZero
+	// RoundHalfEven rounds to the nearest, and ties to even.
+	RoundHalfEven
+	// RoundHalfUp rounds to the nearest, and ties toward +Inf.
+	RoundHalfUp
+	// RoundHalfDown rounds to the nearest, and ties toward -Inf.
+	RoundHalfDown
+	// RoundFloor rounds toward -Inf.
+	RoundFloor
+	// RoundCeil rounds toward +Inf.
+	RoundCeil
+	// RoundTrunc rounds toward zero.
+	RoundTrunc
+	// RoundAwayFromZero rounds away from zero.
+	RoundAwayFromZero
+)
+
+var roundModeNames = []string{
+	"HalfAwayFromZero",
+	"HalfTowardZero",
+	"HalfEven",
+	"HalfUp",
+	"HalfDown",
+	"Floor",
+	"Ceil",
+	"Trunc",
+	"AwayFromZero",
+}
+
+// String is implementation of fmt.Stringer.
+func (m RoundMode) String() string {
+	if m < 0 || int(m) >= len(roundModeNames) {
+		return "RoundMode(" + strconv.Itoa(int(m)) + ")"
+	}
+	return roundModeNames[m]
+}
+
+// RoundPM returns x rounded by given rounding mode with specified precision of base.
+// For base 10, the result is the nearest float64 value to the exact result for the shortest decimal representation of x,
+// so 1.005 is rounded like the decimal 1.005. For the other bases, the exact binary value of x is rounded.
+// It returns x if x is NaN, infinity, or |x*base^prec| isn't less than 2^53.
+// If the result overflows float64, it returns ±Inf. So FloorPB(-2.5, -400, 10) is -Inf, because the step 10^400 overflows.
+// It panics unless base and mode are in valid range.
+func RoundPM(x float64, prec int, base int, mode RoundMode) float64 {
+	panicForInvalidBase(base)
+	panicForInvalidRoundMode(mode)
+	return roundPM(x, prec, base, mode, 64)
+}
+
+// RoundPM32 is similar with RoundPM except that x is float32.
+// It returns x if x is NaN, infinity, or |x*base^prec| isn't less than 2^24.
+// It panics unless base and mode are in valid range.
+func RoundPM32(x float32, prec int, base int, mode RoundMode) float32 {
+	panicForInvalidBase(base)
+	panicForInvalidRoundMode(mode)
+	return float32(roundPM(float64(x), prec, base, mode, 32))
+}
+
+// roundPM rounds x by given precision of base and mode exactly.
+// For base 10, the shortest decimal representation of x for bitSize is used as the exact value of x.
+// The result is rounded to float32 if bitSize is 32.
+func roundPM(x float64, prec int, base int, mode RoundMode, bitSize int) float64 {
+	if x == 0 || math.IsNaN(x) || math.IsInf(x, 0) {
+		return x
+	}
+	b := float64(base)
+	limit := float64(1 << 53)
+	if bitSize == 32 {
+		limit = 1 << 24
+	}
+	if f, ok := roundPMFloat(x, prec, base, mode, bitSize, limit); ok {
+		return f
+	}
+	if !(math.Abs(x)*math.Pow(b, float64(prec/2))*math.Pow(b, float64(prec-prec/2)) < limit) {
+		return x
+	}
+	if prec >= 0 && x == math.Trunc(x) {
+		return x
+	}
+	if math.Pow(b, float64(prec)) == 0 {
+		// |x*base^prec| is too small to compute the power exactly, and the result is 0 or an infinity.
+		if (mode == RoundFloor && x < 0) || (mode == RoundCeil && x > 0) || mode == RoundAwayFromZero {
+			return math.Copysign(math.Inf(+1), x)
+		}
+		return math.Copysign(0, x)
+	}
+	var q *big.Rat
+	if base == 10 {
+		q, _ = new(big.Rat).SetString(strconv.FormatFloat(x, 'g', -1, bitSize))
+	} else {
+		q = new(big.Rat).SetFloat64(x)
+	}
+	r := precRat(prec, base)
+	n := roundBigRatMode(q.Mul(q, r), mode)
+	if n.Sign() == 0 {
+		return math.Copysign(0, x)
+	}
+	q.Quo(q.SetInt(n), r)
+	if bitSize == 32 {
+		f, _ := q.Float32()
+		return float64(f)
+	}
+	f, _ := q.Float64()
+	return f
+}
+
+// roundPMFloat is the fast path of roundPM in floating point.
+// It succeeds if x scaled by the exact power of base is exact, or it's far enough from the rounding boundaries
+// that neither the rounding error of scaling nor the shortest decimal representation of x for base 10 can cross them.
+// Then the result of roundPM is a single rounding of the rounded integer and the power.
+// It fails if |x*base^prec| isn't less than limit, so that roundPM can return x.
+func roundPMFloat(x float64, prec int, base int, mode RoundMode, bitSize int, limit float64) (float64, bool) {
+	n := prec
+	if n < 0 {
+		n = -n
+	}
+	k := 1.0
+	for i := 0; i < n; i++ {
+		k *= float64(base)
+		if k > 1<<53 {
+			return 0, false
+		}
+	}
+	var y float64
+	var exact bool
+	if prec >= 0 {
+		y = x * k
+		exact = math.FMA(x, k, -y) == 0
+	} else {
+		y = x / k
+		exact = math.FMA(y, k, -x) == 0
+	}
+	if !(math.Abs(y) < limit) {
+		return 0, false
+	}
+	var margin float64
+	if !exact {
+		margin = 2 * (math.Nextafter(math.Abs(y), math.Inf(+1)) - math.Abs(y))
+	}
+	if base == 10 && (!exact || bitSize == 32) {
+		// the shortest decimal representation of x is in half ulp of x.
+		u := math.Nextafter(math.Abs(x), math.Inf(+1)) - math.Abs(x)
+		if bitSize == 32 {
+			u = float64(math.Nextafter32(float32(math.Abs(x)), float32(math.Inf(+1)))) - math.Abs(x)
+		}
+		if prec >= 0 {
+			margin += u * k
+		} else {
+			margin += u / k
+		}
+	}
+	if margin > 0 {
+		a := math.Abs(y)
+		frac := a - math.Floor(a)
+		var dist float64
+		switch mode {
+		case RoundFloor, RoundCeil, RoundTrunc, RoundAwayFromZero:
+			dist = math.Min(frac, 1-frac)
+		default:
+			dist = math.Abs(frac - 0.5)
+		}
+		if !(dist > margin) {
+			return 0, false
+		}
+	}
+	m := roundFloatMode(y, mode)
+	if m == 0 {
+		return math.Copysign(0, x), true
+	}
+	var f float64
+	if prec >= 0 {
+		f = m / k
+		if bitSize == 32 && math.FMA(f, k, -m) != 0 {
+			return 0, false
+		}
+	} else {
+		f = m * k
+		if math.IsInf(f, 0) || math.FMA(m, k, -f) != 0 {
+			return 0, false
+		}
+	}
+	return f, true
+}
+
+// roundFloatMode returns the integer value of y rounded by given mode.
+// |y| must be less than 2^53.
+func roundFloatMode(y float64, mode RoundMode) float64 {
+	switch mode {
+	case RoundFloor:
+		return math.Floor(y)
+	case RoundCeil:
+		return math.Ceil(y)
+	case RoundTrunc:
+		return math.Trunc(y)
+	case RoundAwayFromZero:
+		if y < 0 {
+			return math.Floor(y)
+		}
+		return math.Ceil(y)
+	}
+	// the fraction of y is exact only when it's taken toward zero.
+	t := math.Trunc(y)
+	switch frac := math.Abs(y - t); {
+	case frac > 0.5:
+		return t + math.Copysign(1, y)
+	case frac < 0.5:
+		return t
+	}
+	n := math.Floor(y)
+	up := false
+	switch mode {
+	case RoundHalfAwayFromZero:
+		up = y > 0
+	case RoundHalfTowardZero:
+		up = y < 0
+	case RoundHalfEven:
+		up = math.Mod(n, 2) != 0
+	case RoundHalfUp:
+		up = true
+	}
+	if up {
+		return n + 1
+	}
+	return n
+}
+
+// roundBigRatMode returns the integer value of x rounded by given mode.
+func roundBigRatMode(x *big.Rat, mode RoundMode) *big.Int {
+	n, r := new(big.Int).QuoRem(x.Num(), x.Denom(), new(big.Int))
+	if r.Sign() < 0 {
+		n.Sub(n, big.NewInt(1))
+		r.Add(r, x.Denom())
+	}
+	if r.Sign() == 0 {
+		return n
+	}
+	up := false
+	switch mode {
+	case RoundFloor:
+	case RoundCeil:
+		up = true
+	case RoundTrunc:
+		up = x.Sign() < 0
+	case RoundAwayFromZero:
+		up = x.Sign() > 0
+	default:
+		switch t := r.Lsh(r, 1).Cmp(x.Denom()); {
+		case t > 0:
+			up = true
+		case t < 0:
+		case mode == RoundHalfAwayFromZero:
+			up = x.Sign() > 0
+		case mode == RoundHalfTowardZero:
+			up = x.Sign() < 0
+		case mode == RoundHalfEven:
+			up = n.Bit(0) != 0
+		case mode == RoundHalfUp:
+			up = true
+		}
+	}
+	if up {
+		n.Add(n, big.NewInt(1))
+	}
+	return n
 }
 
 // Max returns the larger of x...
@@ -88,14 +404,7 @@ func RoundPB(x float64, prec int, base int) float64 {
 //	Max(x) = x
 //	Max() = +Inf
 func Max(x ...float64) float64 {
-	if len(x) <= 0 {
-		return math.Inf(+1)
-	}
-	result := math.Inf(-1)
-	for _, a := range x {
-		result = math.Max(a, result)
-	}
-	return result
+	return MaxOf(x...)
 }
 
 // Min returns the smaller of x...
@@ -107,14 +416,7 @@ func Max(x ...float64) float64 {
 //	Min(x) = x
 //	Min() = -Inf
 func Min(x ...float64) float64 {
-	if len(x) <= 0 {
-		return math.Inf(-1)
-	}
-	result := math.Inf(+1)
-	for _, a := range x {
-		result = math.Min(a, result)
-	}
-	return result
+	return MinOf(x...)
 }
 
 // MaxMin returns the max, min values in this order, similar with Max and Min functions.
@@ -133,16 +435,7 @@ func MaxMin(x ...float64) (max float64, min float64) {
 //	MinMax(x) = x, x
 //	MinMax() = -Inf, +Inf
 func MinMax(x ...float64) (min float64, max float64) {
-	if len(x) <= 0 {
-		return math.Inf(-1), math.Inf(+1)
-	}
-	min = math.Inf(+1)
-	max = math.Inf(-1)
-	for _, a := range x {
-		min = math.Min(a, min)
-		max = math.Max(a, max)
-	}
-	return
+	return MinMaxOf(x...)
 }
 
 // MaxInt returns the larger integer of x...
@@ -151,16 +444,7 @@ func MinMax(x ...float64) (min float64, max float64) {
 //	MaxInt(x) = x
 //	MaxInt() = math.MaxInt64
 func MaxInt(x ...int64) int64 {
-	if len(x) <= 0 {
-		return int64(math.MaxInt64)
-	}
-	result := int64(math.MinInt64)
-	for _, a := range x {
-		if a > result {
-			result = a
-		}
-	}
-	return result
+	return MaxOf(x...)
 }
 
 // MinInt returns the smaller integer of x...
@@ -169,16 +453,7 @@ func MaxInt(x ...int64) int64 {
 //	MinInt(x) = x
 //	MinInt() = math.MinInt64
 func MinInt(x ...int64) int64 {
-	if len(x) <= 0 {
-		return int64(math.MinInt64)
-	}
-	result := int64(math.MaxInt64)
-	for _, a := range x {
-		if a < result {
-			result = a
-		}
-	}
-	return result
+	return MinOf(x...)
 }
 
 // MaxMinInt returns the max, min integers in this order, similar with MaxInt and MinInt functions.
@@ -197,20 +472,7 @@ func MaxMinInt(x ...int64) (max int64, min int64) {
 //	MinMaxInt(x) = x, x
 //	MinMaxInt() = math.MinInt64, math.MaxInt64
 func MinMaxInt(x ...int64) (min int64, max int64) {
-	if len(x) <= 0 {
-		return int64(math.MinInt64), int64(math.MaxInt64)
-	}
-	min = int64(math.MaxInt64)
-	max = int64(math.MinInt64)
-	for _, a := range x {
-		if a < min {
-			min = a
-		}
-		if a > max {
-			max = a
-		}
-	}
-	return
+	return MinMaxOf(x...)
 }
 
 // MaxUint returns the larger unsigned integer of x...
@@ -219,16 +481,7 @@ func MinMaxInt(x ...int64) (min int64, max int64) {
 //	MaxUint(x) = x
 //	MaxUint() = math.MaxUint64
 func MaxUint(x ...uint64) uint64 {
-	if len(x) <= 0 {
-		return uint64(math.MaxUint64)
-	}
-	result := uint64(0)
-	for _, a := range x {
-		if a > result {
-			result = a
-		}
-	}
-	return result
+	return MaxOf(x...)
 }
 
 // MinUint returns the smaller unsigned integer of x...
@@ -237,16 +490,7 @@ func MaxUint(x ...uint64) uint64 {
 //	MinUint(x) = x
 //	MinUint() = 0
 func MinUint(x ...uint64) uint64 {
-	if len(x) <= 0 {
-		return uint64(0)
-	}
-	result := uint64(math.MaxUint64)
-	for _, a := range x {
-		if a < result {
-			result = a
-		}
-	}
-	return result
+	return MinOf(x...)
 }
 
 // MaxMinUint returns the max, min unsigned integers in this order, similar with MaxUint and MinUint functions.
@@ -265,20 +509,7 @@ func MaxMinUint(x ...uint64) (max uint64, min uint64) {
 //	MinMaxUint(x) = x, x
 //	MinMaxUint() = 0, math.MaxUint64
 func MinMaxUint(x ...uint64) (min uint64, max uint64) {
-	if len(x) <= 0 {
-		return uint64(0), uint64(math.MaxUint64)
-	}
-	min = uint64(math.MaxUint64)
-	max = uint64(0)
-	for _, a := range x {
-		if a < min {
-			min = a
-		}
-		if a > max {
-			max = a
-		}
-	}
-	return
+	return MinMaxOf(x...)
 }
 
 // Between checks x is between a and b
@@ -627,104 +858,56 @@ func SignInt(x int64) int {
 
 // Sum returns the sum of x...
 func Sum(x ...float64) (sum float64) {
-	for _, y := range x {
-		sum += y
-	}
-	return
+	return SumOf(x...)
 }
 
 // Avg returns the arithmetic mean of x...
 func Avg(x ...float64) (avg float64) {
-	k := float64(len(x))
-	for _, y := range x {
-		avg += y / k
-	}
-	return
+	return AvgOf(x...)
 }
 
 // SumInt returns the floating point of sum of x...
 func SumInt(x ...int64) (sum float64) {
-	for _, y := range x {
-		sum += float64(y)
-	}
-	return
+	return SumOf(x...)
 }
 
 // AvgInt returns the floating point of arithmetic mean of x...
 func AvgInt(x ...int64) (avg float64) {
-	k := float64(len(x))
-	for _, y := range x {
-		avg += float64(y) / k
-	}
-	return
+	return AvgOf(x...)
 }
 
 // SumUint returns the floating point of sum of x...
 func SumUint(x ...uint64) (sum float64) {
-	for _, y := range x {
-		sum += float64(y)
-	}
-	return
+	return SumOf(x...)
 }
 
 // AvgUint returns the floating point of arithmetic mean of x...
 func AvgUint(x ...uint64) (avg float64) {
-	k := float64(len(x))
-	for _, y := range x {
-		avg += float64(y) / k
-	}
-	return
+	return AvgOf(x...)
 }
 
 // SumInt2 returns the sum of x...
 // If the result overflows, it returns overflow is true.
 func SumInt2(x ...int64) (sum int64, overflow bool) {
-	var last int64
-	for _, y := range x {
-		sum += y
-		signLast, signSum, signY := SignInt(last), SignInt(sum), SignInt(y)
-		if !overflow && signLast != signSum && signLast == signY {
-			overflow = true
-		}
-		last = sum
-	}
-	return
+	return SumIntOf(x...)
 }
 
 // AvgInt2 returns the arithmetic mean of x...
 // If the result overflows, it returns overflow is true.
 func AvgInt2(x ...int64) (avg int64, overflow bool) {
-	var sum int64
-	sum, overflow = SumInt2(x...)
-	if count := len(x); count > 0 {
-		avg = sum / int64(count)
-	}
-	return
+	return AvgIntOf(x...)
 }
 
 // SumUint2 returns the sum of x...
 // If the result overflows, it returns overflow is true.
 func SumUint2(x ...uint64) (sum uint64, overflow bool) {
-	var last uint64
-	for _, y := range x {
-		sum += y
-		if !overflow && sum < last {
-			overflow = true
-		}
-		last = sum
-	}
-	return
+	return SumIntOf(x...)
 }
 
 // AvgUint2 returns the arithmetic mean of x...
 // If the result overflows, it returns overflow is true.
 func AvgUint2(x ...uint64) (avg uint64, overflow bool) {
-	var sum uint64
-	sum, overflow = SumUint2(x...)
-	if count := len(x); count > 0 {
-		avg = sum / uint64(count)
-	}
-	return
+	return AvgIntOf(x...)
 }
 
 func panicForInvalidBase(base int) {
@@ -733,6 +916,18 @@ func panicForInvalidBase(base int) {
 	}
 }
 
+func panicForInvalidRoundMode(mode RoundMode) {
+	if mode < RoundHalfAwayFromZero || mode > RoundAwayFromZero {
+		panic("invalid round mode")
+	}
+}
+
+func panicForLengthMismatch(n, m int) {
+	if n != m {
+		panic("length mismatch")
+	}
+}
+
 func panicForNaN(x float64) {
 	if math.IsNaN(x) {
 		panic("NaN value")
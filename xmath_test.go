@@ -3,6 +3,7 @@ package xmath_test
 import (
 	"fmt"
 	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/goinsane/xmath"
@@ -68,6 +69,20 @@ func ExampleCeilP() {
 	// ceil of -3.1416 with precision 2: -3.1400
 }
 
+func ExampleRound() {
+	for _, x := range []float64{2.5, -2.5, 0.49999999999999994, -0.49999999999999994, 3.7, -0.3} {
+		fmt.Printf("%v: round %v, round with precision 0 %v\n", x, xmath.Round(x), xmath.RoundP(x, 0))
+	}
+
+	// Output:
+	// 2.5: round 3, round with precision 0 3
+	// -2.5: round -3, round with precision 0 -3
+	// 0.49999999999999994: round 0, round with precision 0 0
+	// -0.49999999999999994: round -0, round with precision 0 -0
+	// 3.7: round 4, round with precision 0 4
+	// -0.3: round -0, round with precision 0 -0
+}
+
 func ExampleRoundP() {
 	fmt.Printf("round of +3.1416 with precision 2: %+6.4f\n", xmath.RoundP(+3.1416, 2))
 	fmt.Printf("round of -3.1416 with precision 3: %+6.4f\n", xmath.RoundP(-3.1416, 3))
@@ -77,6 +92,151 @@ func ExampleRoundP() {
 	// round of -3.1416 with precision 3: -3.1420
 }
 
+func ExampleRoundP_edgeCases() {
+	for _, x := range []float64{1.005, 13.8, -0.913, 89.85, -1.255, 2.5, -2.5, -0.0001, 1e300, 4503599627370495.5, math.Copysign(0, -1), math.Inf(+1), math.NaN()} {
+		fmt.Printf("%v: floor %v, ceil %v, round %v\n", x, xmath.FloorP(x, 2), xmath.CeilP(x, 2), xmath.RoundP(x, 2))
+	}
+
+	// Output:
+	// 1.005: floor 1, ceil 1.01, round 1.01
+	// 13.8: floor 13.8, ceil 13.8, round 13.8
+	// -0.913: floor -0.92, ceil -0.91, round -0.91
+	// 89.85: floor 89.85, ceil 89.85, round 89.85
+	// -1.255: floor -1.26, ceil -1.25, round -1.26
+	// 2.5: floor 2.5, ceil 2.5, round 2.5
+	// -2.5: floor -2.5, ceil -2.5, round -2.5
+	// -0.0001: floor -0.01, ceil -0, round -0
+	// 1e+300: floor 1e+300, ceil 1e+300, round 1e+300
+	// 4.5035996273704955e+15: floor 4.5035996273704955e+15, ceil 4.5035996273704955e+15, round 4.5035996273704955e+15
+	// -0: floor -0, ceil -0, round -0
+	// +Inf: floor +Inf, ceil +Inf, round +Inf
+	// NaN: floor NaN, ceil NaN, round NaN
+}
+
+func ExampleRoundPB() {
+	for _, x := range []float64{2.5, -2.5, 0.1, 123456.5, 1.2345678901234567e-300} {
+		fmt.Printf("%v: floor %v, ceil %v, round %v\n", x, xmath.FloorPB(x, 0, 10), xmath.CeilPB(x, 0, 10), xmath.RoundPB(x, 0, 10))
+		fmt.Printf("%v: floor %v, ceil %v, round %v\n", x, xmath.FloorPB(x, 3, 2), xmath.CeilPB(x, 3, 2), xmath.RoundPB(x, 3, 2))
+		fmt.Printf("%v: floor %v, ceil %v, round %v\n", x, xmath.FloorPB(x, -2, 10), xmath.CeilPB(x, -2, 10), xmath.RoundPB(x, -2, 10))
+		fmt.Printf("%v: floor %v, ceil %v, round %v\n", x, xmath.FloorPB(x, 310, 10), xmath.CeilPB(x, 310, 10), xmath.RoundPB(x, 310, 10))
+		fmt.Printf("%v: floor %v, ceil %v, round %v\n", x, xmath.FloorPB(-x, -400, 10), xmath.CeilPB(-x, -400, 10), xmath.RoundPB(-x, -400, 10))
+	}
+
+	// Output:
+	// 2.5: floor 2, ceil 3, round 3
+	// 2.5: floor 2.5, ceil 2.5, round 2.5
+	// 2.5: floor 0, ceil 100, round 0
+	// 2.5: floor 2.5, ceil 2.5, round 2.5
+	// 2.5: floor -Inf, ceil -0, round -0
+	// -2.5: floor -3, ceil -2, round -3
+	// -2.5: floor -2.5, ceil -2.5, round -2.5
+	// -2.5: floor -100, ceil -0, round -0
+	// -2.5: floor -2.5, ceil -2.5, round -2.5
+	// -2.5: floor 0, ceil +Inf, round 0
+	// 0.1: floor 0, ceil 1, round 0
+	// 0.1: floor 0, ceil 0.125, round 0.125
+	// 0.1: floor 0, ceil 100, round 0
+	// 0.1: floor 0.1, ceil 0.1, round 0.1
+	// 0.1: floor -Inf, ceil -0, round -0
+	// 123456.5: floor 123456, ceil 123457, round 123457
+	// 123456.5: floor 123456.5, ceil 123456.5, round 123456.5
+	// 123456.5: floor 123400, ceil 123500, round 123500
+	// 123456.5: floor 123456.5, ceil 123456.5, round 123456.5
+	// 123456.5: floor -Inf, ceil -0, round -0
+	// 1.2345678901234568e-300: floor 0, ceil 1, round 0
+	// 1.2345678901234568e-300: floor 0, ceil 0.125, round 0
+	// 1.2345678901234568e-300: floor 0, ceil 100, round 0
+	// 1.2345678901234568e-300: floor 1.2345678901e-300, ceil 1.2345678902e-300, round 1.2345678901e-300
+	// 1.2345678901234568e-300: floor -Inf, ceil -0, round -0
+}
+
+func ExampleRoundPB_exactGrid() {
+	fmt.Println(xmath.FloorPB(0.1, 56, 2), xmath.CeilPB(0.1, 56, 2), xmath.RoundPB(0.1, 56, 2))
+	fmt.Println(xmath.FloorPB(0.3, 54, 2), xmath.CeilPB(0.3, 54, 2), xmath.RoundPB(0.3, 54, 2))
+	fmt.Println(xmath.FloorPB(0.1, 54, 2), xmath.CeilPB(0.1, 54, 2))
+	fmt.Println(xmath.FloorPB(0.625, 3, 2), xmath.CeilPB(-0.625, 3, 2), xmath.RoundPB(0.6875, 3, 2), xmath.RoundPB(-0.6875, 3, 2))
+	fmt.Println(xmath.FloorPB(1.0/3, 1, 3), xmath.CeilPB(2.0/3, 1, 3), xmath.FloorPB(0.75, 1, 16), xmath.CeilPB(0.75, 1, 16))
+	fmt.Println(xmath.FloorPB(-2.5, -400, 10), xmath.CeilPB(-2.5, -400, 10), xmath.FloorPB(2.5, -400, 10), xmath.CeilPB(2.5, -400, 10))
+
+	// Output:
+	// 0.1 0.1 0.1
+	// 0.3 0.3 0.3
+	// 0.09999999999999998 0.10000000000000003
+	// 0.625 -0.625 0.75 -0.75
+	// 0 0.6666666666666666 0.75 0.75
+	// -Inf -0 0 +Inf
+}
+
+func ExampleRoundPM() {
+	modes := []xmath.RoundMode{
+		xmath.RoundHalfAwayFromZero,
+		xmath.RoundHalfTowardZero,
+		xmath.RoundHalfEven,
+		xmath.RoundHalfUp,
+		xmath.RoundHalfDown,
+		xmath.RoundFloor,
+		xmath.RoundCeil,
+		xmath.RoundTrunc,
+		xmath.RoundAwayFromZero,
+	}
+	for _, mode := range modes {
+		fmt.Printf("%-16v", mode)
+		for _, x := range []float64{0.25, -0.25, 0.35, -0.35, 0.26, -0.26, 0.3} {
+			fmt.Printf(" %+4.1f", xmath.RoundPM(x, 1, 10, mode))
+		}
+		fmt.Println()
+	}
+
+	// Output:
+	// HalfAwayFromZero +0.3 -0.3 +0.4 -0.4 +0.3 -0.3 +0.3
+	// HalfTowardZero   +0.2 -0.2 +0.3 -0.3 +0.3 -0.3 +0.3
+	// HalfEven         +0.2 -0.2 +0.4 -0.4 +0.3 -0.3 +0.3
+	// HalfUp           +0.3 -0.2 +0.4 -0.3 +0.3 -0.3 +0.3
+	// HalfDown         +0.2 -0.3 +0.3 -0.4 +0.3 -0.3 +0.3
+	// Floor            +0.2 -0.3 +0.3 -0.4 +0.2 -0.3 +0.3
+	// Ceil             +0.3 -0.2 +0.4 -0.3 +0.3 -0.2 +0.3
+	// Trunc            +0.2 -0.2 +0.3 -0.3 +0.2 -0.2 +0.3
+	// AwayFromZero     +0.3 -0.3 +0.4 -0.4 +0.3 -0.3 +0.3
+}
+
+func ExampleRoundPM32() {
+	for _, x := range []float32{1.005, 1.015, -1.005, 13.8, 1e7, 0.1} {
+		fmt.Println(x, xmath.RoundPM32(x, 2, 10, xmath.RoundHalfEven), xmath.RoundPM32(x, 2, 10, xmath.RoundCeil), xmath.RoundPM32(x, 3, 2, xmath.RoundFloor))
+	}
+
+	// Output:
+	// 1.005 1 1.01 1
+	// 1.015 1.02 1.02 1
+	// -1.005 -1 -1 -1.125
+	// 13.8 13.8 13.8 13.75
+	// 1e+07 1e+07 1e+07 1e+07
+	// 0.1 0.1 0.1 0
+}
+
+func ExampleStochasticRoundPB() {
+	for _, u := range []float64{0.2, 0.5, 0.8} {
+		rnd := func() float64 { return u }
+		fmt.Println(xmath.StochasticRoundPB(2.25, 1, 10, rnd), xmath.StochasticRoundPB(-2.25, 1, 10, rnd), xmath.StochasticRoundPB(2.3, 1, 10, rnd))
+	}
+	r := rand.New(rand.NewSource(1))
+	src := make([]float64, 10000)
+	for i := range src {
+		src[i] = 0.123
+	}
+	dst := make([]float64, len(src))
+	xmath.StochasticRoundPBSlice(dst, src, 1, 10, r.Float64)
+	fmt.Println(xmath.RoundP(xmath.Avg(dst...), 2))
+	xmath.StochasticRoundPBSlice(dst, src, 1, 10, nil)
+	fmt.Println(xmath.RoundP(xmath.Avg(dst...), 1))
+
+	// Output:
+	// 2.3 -2.2 2.3
+	// 2.2 -2.3 2.3
+	// 2.2 -2.3 2.3
+	// 0.12
+	// 0.1
+}
+
 func ExampleMaxMin() {
 	list := []float64{-1.215, -1.4142, +3.1416}
 	max, min := xmath.MaxMin(list...)